@@ -0,0 +1,69 @@
+package dcel
+
+import "errors"
+
+// BuildAlphaShape computes the alpha shape of the given points: the Delaunay triangulation of the
+// points, built with BuildConstrainedDelaunay, from which every triangle whose circumradius is
+// larger than alpha has been removed. Smaller values of alpha carve deeper into the convex hull,
+// which is obtained for a large enough alpha.
+//
+// The bounded faces of the result are the remaining triangles. The rest of the plane, including
+// any holes left inside the shape and the gaps between its separate parts, is the OuterFace,
+// whose HalfEdge is on the boundary enclosing the largest area, while each other boundary of the
+// region is one of its InnerComponents. Points that are not a vertex of a remaining triangle are
+// dropped. An error is returned if the triangulation can not be built, or if no triangle is left.
+func BuildAlphaShape(points [][2]int, alpha float64) (*DCEL, error) {
+	d, err := BuildConstrainedDelaunay(points, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	removed := make(map[*Face]bool)
+	kept := make(map[*Face]bool)
+	for _, f := range d.boundedFaces() {
+		if _, _, r, err := f.Circumcircle(); err != nil || r > alpha {
+			removed[f] = true
+		} else {
+			kept[f] = true
+		}
+	}
+	if len(kept) == 0 {
+		return nil, errors.New("no triangle has a circumradius of at most alpha")
+	}
+	for _, he := range d.HalfEdges {
+		if removed[he.Face] {
+			he.Face = d.OuterFace
+		}
+	}
+
+	removedEdges := make(map[*HalfEdge]bool)
+	used := make(map[*Vertex]bool)
+	for _, he := range d.HalfEdges {
+		if he.Face == d.OuterFace && he.Twin.Face == d.OuterFace {
+			removedEdges[he] = true
+		} else {
+			used[he.Target] = true
+		}
+	}
+	removedVertices := make(map[*Vertex]bool)
+	for _, v := range d.Vertices {
+		if !used[v] {
+			removedVertices[v] = true
+		}
+	}
+	d.remove(removedVertices, removedEdges, removed)
+	if err := d.RebuildFaces(); err != nil {
+		return nil, err
+	}
+
+	// RebuildFaces makes a face of the region enclosed by every hole of the shape; merge them into
+	// the outer face, together with the parts of the shape lying in them
+	holes := make(map[*Face]bool)
+	for _, f := range d.boundedFaces() {
+		if !kept[f] {
+			holes[f] = true
+		}
+	}
+	d.mergeIntoOuter(holes)
+	return d, nil
+}
@@ -0,0 +1,167 @@
+package dcel
+
+import "errors"
+
+// SymmetricDifference overlays the faces a and b, which may belong to different structures, and
+// returns a new structure whose bounded faces are the regions covered by exactly one of them.
+// The holes of a and b are taken into account, so a region inside a hole of a is not covered by
+// a. The Data of each resulting face is the face, a or b, that covers it, and the faces are
+// counter-clockwise. A region with holes in it, e.g. where b lies inside a without touching its
+// boundary, is a single face whose InnerComponents run along the holes. The rest of the plane is
+// the OuterFace, whose HalfEdge is on the boundary enclosing the largest area, while each other
+// boundary of the region is one of its InnerComponents. If the faces cover exactly the same
+// region, the returned structure is empty. Neither face is modified.
+//
+// The boundaries are overlaid with the same machinery as PlanarizeEdges, so overlapping edges are
+// merged and, as coordinates are integers, points where edges cross are rounded to the nearest
+// integer point. An error is returned if either face has no closed boundary.
+func SymmetricDifference(a, b *Face) (*DCEL, error) {
+	return overlay([]*Face{a, b}, func(inside []bool) *Face {
+		switch {
+		case inside[0] && !inside[1]:
+			return a
+		case inside[1] && !inside[0]:
+			return b
+		}
+		return nil
+	})
+}
+
+// FaceUnion overlays the faces a and b like SymmetricDifference, but returns a structure whose
+// bounded faces are the regions covered by at least one of them, with nil Data. Where the faces
+// overlap or share edges they are merged into one face, while disjoint faces stay separate, and
+// any region enclosed by the union without being covered is a hole of the face around it.
+func FaceUnion(a, b *Face) (*DCEL, error) {
+	d, err := overlay([]*Face{a, b}, func(inside []bool) *Face {
+		if inside[0] || inside[1] {
+			return a
+		}
+		return nil
+	})
+	if err == nil {
+		for _, f := range d.boundedFaces() {
+			f.Data = nil
+		}
+	}
+	return d, err
+}
+
+// FaceIntersection overlays the faces a and b like SymmetricDifference, but returns a structure
+// whose bounded faces are the regions covered by both of them, with nil Data. The intersection
+// may consist of several separate faces, and is empty if the faces are disjoint or only touch
+// along their boundaries. If one face lies inside the other, outside of its holes, the result is
+// that face.
+func FaceIntersection(a, b *Face) (*DCEL, error) {
+	d, err := overlay([]*Face{a, b}, func(inside []bool) *Face {
+		if inside[0] && inside[1] {
+			return a
+		}
+		return nil
+	})
+	if err == nil {
+		for _, f := range d.boundedFaces() {
+			f.Data = nil
+		}
+	}
+	return d, err
+}
+
+// overlay overlays the given faces and returns a structure whose bounded faces are the regions
+// for which cover, called with whether a region is inside each of the faces, returns a face, which
+// becomes the Data of the region. Edges between regions with the same result of cover are
+// removed, so such regions are merged. The faces are built as described for SymmetricDifference.
+func overlay(faces []*Face, cover func(inside []bool) *Face) (*DCEL, error) {
+	rings := make([][][]*HalfEdge, len(faces))
+	boxes := make([][4]int, len(faces))
+	for i, f := range faces {
+		outer := f.boundary()
+		if len(outer) == 0 {
+			return nil, errors.New("face has no closed boundary")
+		}
+		rings[i] = [][]*HalfEdge{outer}
+		for _, hole := range f.InnerComponents {
+			if edges := closedCycle(hole); len(edges) > 0 {
+				rings[i] = append(rings[i], edges)
+			}
+		}
+		minX, minY, maxX, maxY := f.BoundingBox()
+		boxes[i] = [4]int{minX, minY, maxX, maxY}
+	}
+
+	d := NewDCEL()
+	vertexAt := d.vertexIndex()
+	for i := range faces {
+		for _, ring := range rings[i] {
+			for i, he := range ring {
+				prev := ring[(i+len(ring)-1)%len(ring)].Target
+				from, to := vertexAt(prev.X, prev.Y), vertexAt(he.Target.X, he.Target.Y)
+				if from == to {
+					continue
+				}
+				e, tw := d.addHalfEdge(nil, to), d.addHalfEdge(nil, from)
+				e.Twin, tw.Twin = tw, e
+			}
+		}
+	}
+	if err := d.planarize(); err != nil {
+		return nil, err
+	}
+	if err := d.RebuildFaces(); err != nil {
+		return nil, err
+	}
+
+	// Every face of the overlay lies entirely inside or outside of each of the given faces, so a
+	// single point strictly inside the face tells which of them cover it
+	covering := make(map[*Face]*Face)
+	inside := make([]bool, len(faces))
+	for _, f := range d.boundedFaces() {
+		x, y := f.InteriorPoint()
+		for i, box := range boxes {
+			inside[i] = float64(box[0]) < x && x < float64(box[2]) && float64(box[1]) < y && y < float64(box[3]) &&
+				signedDistance(rings[i], x, y) > 0
+		}
+		covering[f] = cover(inside)
+	}
+
+	source := make(map[*HalfEdge]*Face)
+	removedEdges := make(map[*HalfEdge]bool)
+	used := make(map[*Vertex]bool)
+	for _, he := range d.HalfEdges {
+		if covering[he.Face] == covering[he.Twin.Face] {
+			removedEdges[he] = true
+			continue
+		}
+		source[he] = covering[he.Face]
+		used[he.Target] = true
+	}
+	for _, he := range d.HalfEdges {
+		he.Face = nil
+	}
+	removedVertices := make(map[*Vertex]bool)
+	for _, v := range d.Vertices {
+		if !used[v] {
+			removedVertices[v] = true
+		}
+	}
+	d.remove(removedVertices, removedEdges, nil)
+	if len(d.HalfEdges) == 0 {
+		d.Faces, d.OuterFace = nil, nil
+		return d, nil
+	}
+	if err := d.RebuildFaces(); err != nil {
+		return nil, err
+	}
+	// The uncovered regions, such as the region covered by both faces, are merged into the
+	// OuterFace together with the parts of the result lying in them
+	gaps := make(map[*Face]bool)
+	for _, f := range d.boundedFaces() {
+		if source[f.HalfEdge] == nil {
+			gaps[f] = true
+		}
+	}
+	d.mergeIntoOuter(gaps)
+	for _, f := range d.boundedFaces() {
+		f.Data = source[f.HalfEdge]
+	}
+	return d, nil
+}
@@ -0,0 +1,88 @@
+package dcel
+
+import (
+	"errors"
+	"math"
+)
+
+// bufferSegments is the number of sides of the polygon that approximates a circle in Buffer.
+const bufferSegments = 16
+
+// Buffer returns a new structure with every bounded face of the structure offset by distance:
+// grown outward for a positive distance, shrunk inward for a negative one. The grown faces are
+// merged with FaceUnion semantics, so faces that overlap after growing, as well as neighbouring
+// faces, become a single face, with the gaps they enclose as its holes. Shrunk faces stay
+// separate, and faces narrower than twice the distance vanish entirely or break up into several
+// parts. A zero distance returns the union of the bounded faces. The faces of the result have nil
+// Data, and the structure itself is not modified.
+//
+// Corners are joined with round joins: the offset region is the union, or for a negative distance
+// the difference, of the faces and of the region swept by a circle of radius distance moving along
+// every edge on their boundaries, holes included. The circle is approximated by a regular polygon
+// with 16 sides enclosing it, so before rounding the offset is never less than distance, and
+// exceeds it by at most 2% at the corners. As coordinates are integers, the vertices of these
+// polygons and the points where edges cross are rounded to the nearest integer point, so the
+// distance only holds up to that rounding: the boundary of the result may be up to half a unit
+// closer to the faces in each coordinate. An error is returned if the structure has no bounded
+// face with a closed boundary.
+func (d *DCEL) Buffer(distance float64) (*DCEL, error) {
+	var faces []*Face
+	offset := make(map[*Face]bool)
+	for _, f := range d.boundedFaces() {
+		if len(f.boundary()) > 0 {
+			faces = append(faces, f)
+			offset[f] = true
+		}
+	}
+	if len(faces) == 0 {
+		return nil, errors.New("structure has no bounded faces")
+	}
+
+	n := len(faces)
+	if distance != 0 {
+		r := math.Abs(distance) / math.Cos(math.Pi/bufferSegments)
+		for _, he := range d.edges() {
+			if he.Twin == nil || !offset[he.Face] && !offset[he.Twin.Face] {
+				continue
+			}
+			var points [][2]int
+			for _, v := range []*Vertex{he.Origin(), he.Target} {
+				for k := 0; k < bufferSegments; k++ {
+					angle := 2 * math.Pi * float64(k) / bufferSegments
+					x, y := float64(v.X)+r*math.Cos(angle), float64(v.Y)+r*math.Sin(angle)
+					points = append(points, [2]int{int(math.Round(x)), int(math.Round(y))})
+				}
+			}
+			// The convex hull of the two circles is the region swept along the edge
+			hull, err := BuildConvexHull(points)
+			if err != nil {
+				continue
+			}
+			if swept := hull.boundedFaces(); len(swept) == 1 {
+				faces = append(faces, swept[0])
+			}
+		}
+	}
+
+	b, err := overlay(faces, func(inside []bool) *Face {
+		inFace, inSwept := false, false
+		for i, in := range inside {
+			if i < n {
+				inFace = inFace || in
+			} else {
+				inSwept = inSwept || in
+			}
+		}
+		if distance >= 0 && (inFace || inSwept) || distance < 0 && inFace && !inSwept {
+			return faces[0]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range b.boundedFaces() {
+		f.Data = nil
+	}
+	return b, nil
+}
@@ -0,0 +1,211 @@
+package dcel
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// ParallelEdges returns every pair of distinct edges that connect the same two vertices, each edge
+// given by one of its two half-edges. If more than two edges connect the same vertices, a pair is
+// returned for each combination of them. Pairs are ordered by the position of their half-edges in
+// the structure.
+func (d *DCEL) ParallelEdges() [][2]*HalfEdge {
+	groups := make(map[[2]*Vertex][]*HalfEdge)
+	var keys [][2]*Vertex
+	for _, he := range d.edges() {
+		origin := he.Origin()
+		if origin == nil || he.Target == nil {
+			continue
+		}
+		key := [2]*Vertex{origin, he.Target}
+		if _, ok := groups[key]; !ok {
+			key = [2]*Vertex{he.Target, origin}
+		}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], he)
+	}
+
+	var pairs [][2]*HalfEdge
+	for _, key := range keys {
+		group := groups[key]
+		for i := range group {
+			for j := i + 1; j < len(group); j++ {
+				pairs = append(pairs, [2]*HalfEdge{group[i], group[j]})
+			}
+		}
+	}
+	return pairs
+}
+
+// CheckPlanarity verifies that the edges of the structure form a planar embedding: no edge may
+// cross another one, and an edge may only meet another one at their endpoints, so no vertex may
+// lie inside an edge and no collinear edges may overlap. Two edges connecting the same vertices
+// overlap completely, so they are reported too. The meeting points are found with the
+// Bentley-Ottmann algorithm in O((n + k) log n) time for n edges and k meeting points. The first
+// violation found is reported in the returned error, and an error is also returned if an edge has
+// no origin or target vertex.
+func (d *DCEL) CheckPlanarity() error {
+	edges := d.edges()
+	for _, he := range edges {
+		if he.Origin() == nil || he.Target == nil {
+			return fmt.Errorf("half-edge %v has no origin or target vertex", he)
+		}
+	}
+	var err error
+	sweepIntersections(edges, func(x, y *big.Rat, interior []*HalfEdge) {
+		if err == nil {
+			err = fmt.Errorf("edge %v meets another edge at (%s, %s) inside of it", interior[0], x.RatString(), y.RatString())
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if pairs := d.ParallelEdges(); len(pairs) > 0 {
+		return fmt.Errorf("edges %v and %v connect the same vertices", pairs[0][0], pairs[0][1])
+	}
+	return nil
+}
+
+// CheckMembership verifies that every half-edge, vertex and face referenced from the objects
+// stored in the structure is stored in the corresponding slice too. It follows the Next, Prev and
+// Twin pointers from every stored half-edge, as well as the Target and Face pointers of the
+// reached half-edges, the HalfEdge pointers of vertices and faces, the inner components of faces,
+// and OuterFace. This detects objects that were linked into the structure without being created
+// through its factory methods. The first missing object found is reported in the returned error.
+func (d *DCEL) CheckMembership() error {
+	vertices := make(map[*Vertex]bool, len(d.Vertices))
+	for _, v := range d.Vertices {
+		vertices[v] = true
+	}
+	faces := make(map[*Face]bool, len(d.Faces))
+	for _, f := range d.Faces {
+		faces[f] = true
+	}
+	halfEdges := make(map[*HalfEdge]bool, len(d.HalfEdges))
+	for _, he := range d.HalfEdges {
+		halfEdges[he] = true
+	}
+
+	if d.OuterFace != nil && !faces[d.OuterFace] {
+		return fmt.Errorf("outer face %v is not stored in the structure", d.OuterFace)
+	}
+	for _, v := range d.Vertices {
+		if v.HalfEdge != nil && !halfEdges[v.HalfEdge] {
+			return fmt.Errorf("half-edge %v of vertex %v is not stored in the structure", v.HalfEdge, v)
+		}
+	}
+	for _, f := range d.Faces {
+		if f.HalfEdge != nil && !halfEdges[f.HalfEdge] {
+			return fmt.Errorf("half-edge %v of face %v is not stored in the structure", f.HalfEdge, f)
+		}
+		for _, he := range f.InnerComponents {
+			if !halfEdges[he] {
+				return fmt.Errorf("inner component %v of face %v is not stored in the structure", he, f)
+			}
+		}
+	}
+
+	visited := make(map[*HalfEdge]bool)
+	for _, start := range d.HalfEdges {
+		stack := []*HalfEdge{start}
+		for len(stack) > 0 {
+			he := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if he == nil || visited[he] {
+				continue
+			}
+			visited[he] = true
+			if !halfEdges[he] {
+				return fmt.Errorf("half-edge %v is not stored in the structure", he)
+			}
+			if he.Target != nil && !vertices[he.Target] {
+				return fmt.Errorf("target %v of half-edge %v is not stored in the structure", he.Target, he)
+			}
+			if he.Face != nil && !faces[he.Face] {
+				return fmt.Errorf("face %v of half-edge %v is not stored in the structure", he.Face, he)
+			}
+			stack = append(stack, he.Next, he.Prev, he.Twin)
+		}
+	}
+	return nil
+}
+
+// ValidateHoles verifies the inner components of every face: each of them must be a closed cycle
+// of half-edges belonging to the face, lie within the outer boundary of the face if it has one, and
+// be oriented opposite to that boundary. The boundaries of the OuterFace need not nest, as it is
+// unbounded, so only the first condition is checked for its inner components. The first violation
+// found is reported in the returned error.
+func (d *DCEL) ValidateHoles() error {
+	for _, f := range d.Faces {
+		outer := f.boundary()
+		for _, start := range f.InnerComponents {
+			edges := closedCycle(start)
+			if len(edges) == 0 {
+				return fmt.Errorf("inner component %v of face %v is not a closed cycle", start, f)
+			}
+			for _, he := range edges {
+				if he.Face != f {
+					return fmt.Errorf("half-edge %v of inner component of face %v belongs to face %v", he, f, he.Face)
+				}
+			}
+			if len(outer) == 0 || f == d.OuterFace {
+				continue
+			}
+			for _, he := range edges {
+				if classifyRing(outer, he.Target) < 0 {
+					return fmt.Errorf("vertex %v of inner component of face %v lies outside of its boundary", he.Target, f)
+				}
+			}
+			if area, holeArea := ringArea(outer), ringArea(edges); area*holeArea >= 0 {
+				return fmt.Errorf("inner component %v of face %v is not oriented opposite to its boundary", start, f)
+			}
+		}
+	}
+	return nil
+}
+
+// OpenHalfEdges returns every half-edge in the structure that has no twin, in the order they are
+// stored. Such dangling half-edges are usually left behind by an unfinished construction, and make
+// traversals that cross edges stop early.
+func (d *DCEL) OpenHalfEdges() []*HalfEdge {
+	var open []*HalfEdge
+	for _, he := range d.HalfEdges {
+		if he.Twin == nil {
+			open = append(open, he)
+		}
+	}
+	return open
+}
+
+// TJunctions returns the vertices that lie in the interior of an edge they are not an endpoint of,
+// in the order they are stored. Such a vertex is usually the endpoint of another edge that should
+// have split the one it touches, leaving the faces on both sides of that edge without a matching
+// boundary. Edges without an origin or target vertex are ignored.
+func (d *DCEL) TJunctions() []*Vertex {
+	edges := d.edges()
+	var junctions []*Vertex
+	for _, v := range d.Vertices {
+		for _, he := range edges {
+			if origin := he.Origin(); origin != nil && he.Target != nil && onSegment(v, origin, he.Target) {
+				junctions = append(junctions, v)
+				break
+			}
+		}
+	}
+	return junctions
+}
+
+// PendantVertices returns the vertices with exactly one incident edge, in the order they are
+// stored. Such vertices end a dangling edge, which usually means that the construction of the
+// structure was not completed.
+func (d *DCEL) PendantVertices() []*Vertex {
+	var pendant []*Vertex
+	for _, v := range d.Vertices {
+		if v.Degree() == 1 {
+			pendant = append(pendant, v)
+		}
+	}
+	return pendant
+}
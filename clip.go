@@ -0,0 +1,212 @@
+package dcel
+
+import (
+	"errors"
+	"math"
+)
+
+// SplitAtPolygon splits the edges of the structure at every point where they cross or touch the
+// boundary of the clip polygon, given by its vertices in order, so that no edge crosses that
+// boundary anymore. Edges passing through a vertex of the polygon are split there too. Only
+// vertices are inserted: the edges of the polygon are not added to the structure and the faces
+// keep their boundaries, now running through the new vertices. As coordinates are integers,
+// crossing points are rounded to the nearest integer point, reusing an existing vertex at that
+// point if there is one. An error is returned if the polygon has less than three vertices or if a
+// half-edge has no origin or target vertex.
+func (d *DCEL) SplitAtPolygon(clip [][2]int) error {
+	if len(clip) < 3 {
+		return errors.New("clip polygon must have at least three vertices")
+	}
+	edges := d.edges()
+	for _, he := range edges {
+		if he.Origin() == nil || he.Target == nil {
+			return errors.New("half-edge has no origin or target vertex")
+		}
+	}
+
+	defer d.beginOperation(true)()
+	vertexAt := d.vertexIndex()
+
+	corners := make([]*Vertex, len(clip))
+	for i, p := range clip {
+		corners[i] = &Vertex{X: p[0], Y: p[1]}
+	}
+	for _, he := range edges {
+		a, b := he.Origin(), he.Target
+		var splits []*Vertex
+		for i, c := range corners {
+			next := corners[(i+1)%len(corners)]
+			var v *Vertex
+			switch {
+			case segmentsCross(a, b, c, next):
+				v = vertexAt(crossingPoint(a, b, c, next))
+			case onSegment(c, a, b):
+				v = vertexAt(c.X, c.Y)
+			default:
+				continue
+			}
+			if v != a && v != b {
+				splits = append(splits, v)
+			}
+		}
+		d.splitEdgeAtAll(he, splits)
+	}
+	return nil
+}
+
+// ClipToRect returns a new structure containing only the parts of the bounded faces that lie
+// inside the rectangle with the given corners. Faces entirely outside of the rectangle are
+// dropped, while faces crossing its sides are cut along them, so that the cut parts are closed by
+// new edges running along the rectangle. A face cut into several parts is represented by one face
+// per part, all of them with the ID and Data of the original face. The Data of the vertices is
+// copied too. The structure itself is not modified.
+//
+// The faces of the result are rebuilt with RebuildFaces, so the boundary enclosing the largest area
+// is stored as OuterFace, and the boundaries of other connected parts of the result become its
+// InnerComponents, or those of the face they lie in. The regions enclosed by the result without
+// being part of it, such as the holes of the faces, belong to the OuterFace too. As coordinates are integers, points where
+// edges cross the sides of the rectangle are rounded to the nearest integer point. An error is returned if the rectangle is
+// empty or if a half-edge has no twin or no target vertex.
+func (d *DCEL) ClipToRect(minX, minY, maxX, maxY int) (*DCEL, error) {
+	if minX >= maxX || minY >= maxY {
+		return nil, errors.New("clip rectangle is empty")
+	}
+	c := NewDCELWithCapacity(len(d.Vertices)+4, len(d.Faces), len(d.HalfEdges)+8)
+	vertices := make(map[*Vertex]*Vertex, len(d.Vertices))
+	byCoords := make(map[[2]int]*Vertex)
+	for _, v := range d.Vertices {
+		copied := c.NewVertex(v.X, v.Y)
+		copied.Data = v.Data
+		vertices[v] = copied
+		byCoords[[2]int{v.X, v.Y}] = copied
+	}
+	faces := make(map[*Face]*Face, len(d.Faces))
+	for _, f := range d.Faces {
+		if f != d.OuterFace {
+			copied := c.NewFace()
+			copied.ID, copied.Data = f.ID, f.Data
+			faces[f] = copied
+		}
+	}
+	halfEdges := make(map[*HalfEdge]*HalfEdge, len(d.HalfEdges))
+	for _, he := range d.HalfEdges {
+		if he.Twin == nil || he.Target == nil || he.Twin.Target == nil {
+			return nil, errors.New("half-edge has no twin or target vertex")
+		}
+		halfEdges[he] = c.addHalfEdge(faces[he.Face], vertices[he.Target])
+	}
+	for he, copied := range halfEdges {
+		copied.Twin = halfEdges[he.Twin]
+	}
+
+	// Add the sides of the rectangle, without a face, so they can be told apart from the pieces of
+	// the original edges once the edges are split at their crossings.
+	corners := [][2]int{{minX, minY}, {maxX, minY}, {maxX, maxY}, {minX, maxY}}
+	rect := make([]*Vertex, len(corners))
+	for i, p := range corners {
+		v, ok := byCoords[p]
+		if !ok {
+			v = c.NewVertex(p[0], p[1])
+		}
+		rect[i] = v
+	}
+	for i, v := range rect {
+		he, tw := c.addHalfEdge(nil, rect[(i+1)%len(rect)]), c.addHalfEdge(nil, v)
+		he.Twin, tw.Twin = tw, he
+	}
+	if err := c.planarize(); err != nil {
+		return nil, err
+	}
+
+	// Remember the original face on the left of each piece before its face is rebuilt
+	source := make(map[*HalfEdge]*Face, len(c.HalfEdges))
+	for _, he := range c.HalfEdges {
+		source[he] = he.Face
+	}
+	if err := c.RebuildFaces(); err != nil {
+		return nil, err
+	}
+
+	// Every face now lies entirely inside or outside of the rectangle, so a single point strictly
+	// inside the face tells which is the case, even if all of its edges run along the rectangle
+	kept := make(map[*Face]bool)
+	for _, f := range c.boundedFaces() {
+		x, y := f.InteriorPoint()
+		if x <= float64(minX) || x >= float64(maxX) || y <= float64(minY) || y >= float64(maxY) {
+			continue
+		}
+		var original *Face
+		for _, he := range f.HalfEdges() {
+			if original = source[he]; original != nil {
+				break
+			}
+		}
+		if original == nil {
+			// The boundary is made of sides of the rectangle and of edges of the outer face, so the
+			// face that was cut, if any, is found by its position
+			original = faces[d.faceContainingPoint(x, y)]
+		}
+		if original != nil {
+			f.ID, f.Data = original.ID, original.Data
+			kept[f] = true
+		}
+	}
+
+	removedEdges := make(map[*HalfEdge]bool)
+	used := make(map[*Vertex]bool)
+	for _, he := range c.HalfEdges {
+		switch {
+		case kept[he.Face]:
+			used[he.Target] = true
+		case !kept[he.Twin.Face]:
+			removedEdges[he] = true
+		default:
+			he.Face = nil
+		}
+	}
+	removedVertices := make(map[*Vertex]bool)
+	for _, v := range c.Vertices {
+		if !used[v] {
+			removedVertices[v] = true
+		}
+	}
+	c.remove(removedVertices, removedEdges, nil)
+	if len(c.HalfEdges) == 0 {
+		c.Faces, c.OuterFace = nil, nil
+		return c, nil
+	}
+	if err := c.RebuildFaces(); err != nil {
+		return nil, err
+	}
+	// Regions enclosed by the kept faces without belonging to them, such as their holes, are
+	// merged into the OuterFace together with the parts of the result lying in them
+	gaps := make(map[*Face]bool)
+	for _, f := range c.boundedFaces() {
+		if !kept[f] {
+			gaps[f] = true
+		}
+	}
+	c.mergeIntoOuter(gaps)
+	return c, nil
+}
+
+// faceContainingPoint returns the bounded face with the smallest area that contains the point
+// (x, y) strictly inside, outside of its holes, or nil if there is no such face.
+func (d *DCEL) faceContainingPoint(x, y float64) *Face {
+	var found *Face
+	for _, f := range d.boundedFaces() {
+		rings := [][]*HalfEdge{f.boundary()}
+		if len(rings[0]) == 0 {
+			continue
+		}
+		for _, hole := range f.InnerComponents {
+			if edges := closedCycle(hole); len(edges) > 0 {
+				rings = append(rings, edges)
+			}
+		}
+		if signedDistance(rings, x, y) > 0 && (found == nil || math.Abs(f.Area()) < math.Abs(found.Area())) {
+			found = f
+		}
+	}
+	return found
+}
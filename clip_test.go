@@ -0,0 +1,72 @@
+package dcel
+
+import "testing"
+
+// labeledGrid returns a 2x2 grid of 10x10 cells, whose faces store the coordinates of their lower
+// left corners as Data.
+func labeledGrid() *DCEL {
+	d := BuildGrid(2, 2, 10, 10)
+	for _, f := range d.boundedFaces() {
+		minX, minY, _, _ := f.BoundingBox()
+		f.Data = [2]int{minX, minY}
+	}
+	return d
+}
+
+func TestClipToRect(t *testing.T) {
+	tests := []struct {
+		name                   string
+		minX, minY, maxX, maxY int
+		// Data of the original face and area of every face of the result
+		want map[[2]int]float64
+	}{
+		{"whole grid", 0, 0, 20, 20, map[[2]int]float64{{0, 0}: 100, {10, 0}: 100, {0, 10}: 100, {10, 10}: 100}},
+		{"coinciding with a cell", 0, 0, 10, 10, map[[2]int]float64{{0, 0}: 100}},
+		{"coinciding with an inner cell", 10, 10, 20, 20, map[[2]int]float64{{10, 10}: 100}},
+		{"sharing sides with cells", 0, 0, 15, 10, map[[2]int]float64{{0, 0}: 100, {10, 0}: 50}},
+		{"sharing a side with a cell", 5, 0, 10, 10, map[[2]int]float64{{0, 0}: 50}},
+		{"inside a cell", 2, 2, 8, 8, map[[2]int]float64{{0, 0}: 36}},
+		{"crossing cells", 5, 5, 25, 25, map[[2]int]float64{{0, 0}: 25, {10, 0}: 50, {0, 10}: 50, {10, 10}: 100}},
+		{"outside of the grid", 30, 30, 40, 40, map[[2]int]float64{}},
+	}
+	for _, tt := range tests {
+		c, err := labeledGrid().ClipToRect(tt.minX, tt.minY, tt.maxX, tt.maxY)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		faces := c.boundedFaces()
+		if len(faces) != len(tt.want) {
+			t.Errorf("%s: result has %d faces, want %d", tt.name, len(faces), len(tt.want))
+			continue
+		}
+		for _, f := range faces {
+			key, _ := f.Data.([2]int)
+			if area, ok := tt.want[key]; !ok || f.Area() != area {
+				t.Errorf("%s: face of %v has area %v, want %v", tt.name, f.Data, f.Area(), area)
+			}
+		}
+	}
+}
+
+func TestClipToRectHole(t *testing.T) {
+	// A ring of cells, whose hole coincides with the rectangle
+	d, err := FromRaster([][]bool{{true, true, true}, {true, false, true}, {true, true, true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := d.ClipToRect(1, 1, 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if faces := c.boundedFaces(); len(faces) != 0 {
+		t.Errorf("result has %d faces, want none", len(faces))
+	}
+	// A rectangle around the hole keeps the ring with its hole
+	c, err = d.ClipToRect(0, 0, 3, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if faces := c.boundedFaces(); len(faces) != 1 || len(faces[0].InnerComponents) != 1 {
+		t.Errorf("result has faces %v, want one face with a hole", faces)
+	}
+}
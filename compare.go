@@ -0,0 +1,172 @@
+package dcel
+
+// Isomorphic returns true if the two structures describe the same subdivision, ignoring the
+// identity of the objects in them. This is the case if there is a one-to-one correspondence
+// between their vertices, half-edges and faces that preserves the coordinates of the vertices,
+// all Target, Twin, Next, Prev and Face relationships of the half-edges, and the outer face.
+// The choice of Vertex.HalfEdge and Face.HalfEdge, face IDs and Data are not compared.
+func Isomorphic(a, b *DCEL) bool {
+	if len(a.Vertices) != len(b.Vertices) || len(a.HalfEdges) != len(b.HalfEdges) ||
+		len(a.Faces) != len(b.Faces) {
+		return false
+	}
+
+	type edgeKey struct {
+		origin, target [3]int
+	}
+	coords := func(v *Vertex) [3]int {
+		if v == nil {
+			return [3]int{}
+		}
+		return [3]int{v.X, v.Y, 1}
+	}
+	key := func(he *HalfEdge) edgeKey {
+		return edgeKey{coords(he.Origin()), coords(he.Target)}
+	}
+	candidates := make(map[edgeKey][]*HalfEdge)
+	for _, he := range b.HalfEdges {
+		candidates[key(he)] = append(candidates[key(he)], he)
+	}
+
+	m := newIsoMapping()
+	for _, he := range a.HalfEdges {
+		if m.edges[he] != nil {
+			continue
+		}
+		matched := false
+		for _, c := range candidates[key(he)] {
+			if m.edgesInv[c] == nil && m.extend(he, c) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if a.OuterFace != nil || b.OuterFace != nil {
+		if a.OuterFace == nil || b.OuterFace == nil {
+			return false
+		}
+		if f, ok := m.faces[a.OuterFace]; ok && f != b.OuterFace || !ok && m.facesInv[b.OuterFace] != nil {
+			return false
+		}
+	}
+
+	// Vertices without edges are only matched by their coordinates
+	isolated := make(map[[2]int]int)
+	for _, v := range a.Vertices {
+		if m.vertices[v] == nil {
+			isolated[[2]int{v.X, v.Y}]++
+		}
+	}
+	for _, v := range b.Vertices {
+		if m.verticesInv[v] == nil {
+			isolated[[2]int{v.X, v.Y}]--
+		}
+	}
+	for _, count := range isolated {
+		if count != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// isoMapping is a partial one-to-one correspondence between the objects of two structures.
+type isoMapping struct {
+	edges, edgesInv       map[*HalfEdge]*HalfEdge
+	vertices, verticesInv map[*Vertex]*Vertex
+	faces, facesInv       map[*Face]*Face
+}
+
+func newIsoMapping() *isoMapping {
+	return &isoMapping{
+		edges:       make(map[*HalfEdge]*HalfEdge),
+		edgesInv:    make(map[*HalfEdge]*HalfEdge),
+		vertices:    make(map[*Vertex]*Vertex),
+		verticesInv: make(map[*Vertex]*Vertex),
+		faces:       make(map[*Face]*Face),
+		facesInv:    make(map[*Face]*Face),
+	}
+}
+
+// extend tries to map half-edge x to y, and then every half-edge reachable from x through its
+// Twin, Next and Prev pointers to the corresponding one reachable from y. If this leads to a
+// contradiction, all pairs added during the call are removed again and false is returned.
+func (m *isoMapping) extend(x, y *HalfEdge) bool {
+	var addedEdges []*HalfEdge
+	var addedVertices []*Vertex
+	var addedFaces []*Face
+	var queue []*HalfEdge
+
+	pairVertex := func(u, w *Vertex) bool {
+		if u == nil || w == nil {
+			return u == nil && w == nil
+		}
+		if mapped, ok := m.vertices[u]; ok {
+			return mapped == w
+		}
+		if m.verticesInv[w] != nil || u.X != w.X || u.Y != w.Y {
+			return false
+		}
+		m.vertices[u], m.verticesInv[w] = w, u
+		addedVertices = append(addedVertices, u)
+		return true
+	}
+	pairFace := func(f, g *Face) bool {
+		if f == nil || g == nil {
+			return f == nil && g == nil
+		}
+		if mapped, ok := m.faces[f]; ok {
+			return mapped == g
+		}
+		if m.facesInv[g] != nil {
+			return false
+		}
+		m.faces[f], m.facesInv[g] = g, f
+		addedFaces = append(addedFaces, f)
+		return true
+	}
+	pairEdge := func(u, w *HalfEdge) bool {
+		if u == nil || w == nil {
+			return u == nil && w == nil
+		}
+		if mapped, ok := m.edges[u]; ok {
+			return mapped == w
+		}
+		if m.edgesInv[w] != nil || !pairVertex(u.Target, w.Target) || !pairFace(u.Face, w.Face) {
+			return false
+		}
+		m.edges[u], m.edgesInv[w] = w, u
+		addedEdges = append(addedEdges, u)
+		queue = append(queue, u)
+		return true
+	}
+
+	ok := pairEdge(x, y)
+	for ok && len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		w := m.edges[u]
+		ok = pairEdge(u.Twin, w.Twin) && pairEdge(u.Next, w.Next) && pairEdge(u.Prev, w.Prev)
+	}
+	if ok {
+		return true
+	}
+
+	for _, u := range addedEdges {
+		delete(m.edgesInv, m.edges[u])
+		delete(m.edges, u)
+	}
+	for _, u := range addedVertices {
+		delete(m.verticesInv, m.vertices[u])
+		delete(m.vertices, u)
+	}
+	for _, f := range addedFaces {
+		delete(m.facesInv, m.faces[f])
+		delete(m.faces, f)
+	}
+	return false
+}
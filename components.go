@@ -0,0 +1,136 @@
+package dcel
+
+// SplitComponents returns one independent structure per connected component of the graph formed
+// by the vertices and edges of the structure, ordered by the position of their first vertex. The
+// objects of each component are copied, keeping their order, coordinates, IDs, Data and all
+// pointers between them. A bounded face is copied into the component its outer boundary belongs
+// to, together with the holes belonging to the same component. In every component the region
+// around it, which is either the outer face or a face of another component containing it as a
+// hole, becomes OuterFace: a copy of the original outer face if the component borders it, or a
+// new face otherwise. Vertices without edges form components of their own, without any faces. The
+// structure itself is not modified.
+func (d *DCEL) SplitComponents() []*DCEL {
+	parent := make(map[*Vertex]*Vertex)
+	var find func(v *Vertex) *Vertex
+	find = func(v *Vertex) *Vertex {
+		p, ok := parent[v]
+		if !ok {
+			return v
+		}
+		root := find(p)
+		parent[v] = root
+		return root
+	}
+	for _, he := range d.edges() {
+		if origin := he.Origin(); origin != nil && he.Target != nil {
+			if a, b := find(origin), find(he.Target); a != b {
+				parent[b] = a
+			}
+		}
+	}
+
+	index := make(map[*Vertex]int)
+	var groups [][]*Vertex
+	for _, v := range d.Vertices {
+		root := find(v)
+		i, ok := index[root]
+		if !ok {
+			i = len(groups)
+			index[root] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], v)
+	}
+	halfEdgeGroups := make([][]*HalfEdge, len(groups))
+	component := make(map[*HalfEdge]int, len(d.HalfEdges))
+	for _, he := range d.HalfEdges {
+		if he.Target == nil {
+			continue
+		}
+		i := index[find(he.Target)]
+		halfEdgeGroups[i] = append(halfEdgeGroups[i], he)
+		component[he] = i
+	}
+
+	result := make([]*DCEL, len(groups))
+	for i, vertices := range groups {
+		result[i] = d.copyComponent(vertices, halfEdgeGroups[i], func(he *HalfEdge) bool {
+			c, ok := component[he]
+			return ok && c == i
+		})
+	}
+	return result
+}
+
+// copyComponent copies the given vertices and half-edges, which must form a connected component of
+// the structure, into a new structure, as described by SplitComponents. The member function tells
+// whether a half-edge belongs to the component.
+func (d *DCEL) copyComponent(vertices []*Vertex, halfEdges []*HalfEdge, member func(he *HalfEdge) bool) *DCEL {
+	c := NewDCELWithCapacity(len(vertices), 0, len(halfEdges))
+	vertexCopies := make(map[*Vertex]*Vertex, len(vertices))
+	for _, v := range vertices {
+		copied := &Vertex{X: v.X, Y: v.Y, Data: v.Data}
+		vertexCopies[v] = copied
+		c.Vertices = append(c.Vertices, copied)
+	}
+	halfEdgeCopies := make(map[*HalfEdge]*HalfEdge, len(halfEdges))
+	for _, he := range halfEdges {
+		copied := &HalfEdge{Target: vertexCopies[he.Target], Data: he.Data, Constrained: he.Constrained}
+		halfEdgeCopies[he] = copied
+		c.HalfEdges = append(c.HalfEdges, copied)
+	}
+
+	faceCopies := make(map[*Face]*Face)
+	for _, f := range d.Faces {
+		if f == d.OuterFace || f.HalfEdge == nil || !member(f.HalfEdge) {
+			continue
+		}
+		copied := &Face{HalfEdge: halfEdgeCopies[f.HalfEdge], ID: f.ID, Data: f.Data, dcel: c}
+		for _, hole := range f.InnerComponents {
+			if member(hole) {
+				copied.InnerComponents = append(copied.InnerComponents, halfEdgeCopies[hole])
+			}
+		}
+		faceCopies[f] = copied
+		c.Faces = append(c.Faces, copied)
+	}
+
+	// Half-edges of faces that were not copied lie on the boundary of the region around the
+	// component
+	outer := func(he *HalfEdge) *Face {
+		if c.OuterFace == nil {
+			c.OuterFace = &Face{HalfEdge: halfEdgeCopies[he], dcel: c}
+			if he.Face == d.OuterFace {
+				c.OuterFace.ID, c.OuterFace.Data = d.OuterFace.ID, d.OuterFace.Data
+			}
+			c.Faces = append(c.Faces, c.OuterFace)
+		}
+		return c.OuterFace
+	}
+	for _, he := range halfEdges {
+		if d.OuterFace != nil && he.Face == d.OuterFace {
+			outer(he)
+			break
+		}
+	}
+
+	mapped := func(he *HalfEdge) *HalfEdge {
+		if he == nil || !member(he) {
+			return nil
+		}
+		return halfEdgeCopies[he]
+	}
+	for _, he := range halfEdges {
+		copied := halfEdgeCopies[he]
+		copied.Twin, copied.Next, copied.Prev = mapped(he.Twin), mapped(he.Next), mapped(he.Prev)
+		if face, ok := faceCopies[he.Face]; ok {
+			copied.Face = face
+		} else if he.Face != nil {
+			copied.Face = outer(he)
+		}
+	}
+	for _, v := range vertices {
+		vertexCopies[v].HalfEdge = mapped(v.HalfEdge)
+	}
+	return c
+}
@@ -0,0 +1,83 @@
+package dcel
+
+// cycle returns the half-edges linked by Next pointers starting with the given one. The walk stops
+// at a missing Next pointer or when a half-edge is reached for a second time.
+func cycle(start *HalfEdge) []*HalfEdge {
+	return walkCycle(start, func(he *HalfEdge) *HalfEdge { return he.Next })
+}
+
+// reverseCycle returns the half-edges linked by Prev pointers starting with the given one, with
+// the same guards as cycle.
+func reverseCycle(start *HalfEdge) []*HalfEdge {
+	return walkCycle(start, func(he *HalfEdge) *HalfEdge { return he.Prev })
+}
+
+// walkCycle returns the half-edges reached from start by repeatedly calling step, stopping at a
+// nil half-edge or when a half-edge is reached for a second time.
+func walkCycle(start *HalfEdge, step func(he *HalfEdge) *HalfEdge) []*HalfEdge {
+	var edges []*HalfEdge
+	visited := make(map[*HalfEdge]bool)
+	for he := start; he != nil && !visited[he]; he = step(he) {
+		visited[he] = true
+		edges = append(edges, he)
+	}
+	return edges
+}
+
+// closedCycle returns the half-edges linked by Next pointers starting with the given one, if they
+// form a closed cycle back to it in which every half-edge has a target vertex, or nil otherwise.
+func closedCycle(start *HalfEdge) []*HalfEdge {
+	edges := cycle(start)
+	if len(edges) == 0 || edges[len(edges)-1].Next != start {
+		return nil
+	}
+	for _, he := range edges {
+		if he.Target == nil {
+			return nil
+		}
+	}
+	return edges
+}
+
+// ringArea returns the signed area enclosed by a closed cycle of half-edges, positive if the cycle
+// is oriented counter-clockwise.
+func ringArea(edges []*HalfEdge) float64 {
+	var sum int64
+	for i, he := range edges {
+		v, next := he.Target, edges[(i+1)%len(edges)].Target
+		sum += int64(v.X)*int64(next.Y) - int64(next.X)*int64(v.Y)
+	}
+	return float64(sum) / 2
+}
+
+// classifyRing returns 1 if vertex p lies inside the region enclosed by a closed cycle of
+// half-edges, 0 if it lies on the cycle and -1 if it lies outside of it, based on the winding
+// number of the cycle around the point.
+func classifyRing(edges []*HalfEdge, p *Vertex) int {
+	return classifyRingScaled(edges, p, 1)
+}
+
+// classifyRingScaled is like classifyRing, but compares p to the cycle with all of its coordinates
+// multiplied by scale, which allows to classify points with fractional coordinates exactly.
+func classifyRingScaled(edges []*HalfEdge, p *Vertex, scale int) int {
+	winding := 0
+	for i, he := range edges {
+		prev := edges[(i+len(edges)-1)%len(edges)].Target
+		a := &Vertex{X: prev.X * scale, Y: prev.Y * scale}
+		b := &Vertex{X: he.Target.X * scale, Y: he.Target.Y * scale}
+		if a.X == p.X && a.Y == p.Y || onSegment(p, a, b) {
+			return 0
+		}
+		if a.Y <= p.Y {
+			if b.Y > p.Y && orient(a, b, p) > 0 {
+				winding++
+			}
+		} else if b.Y <= p.Y && orient(a, b, p) < 0 {
+			winding--
+		}
+	}
+	if winding != 0 {
+		return 1
+	}
+	return -1
+}
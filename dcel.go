@@ -8,11 +8,15 @@ import (
 )
 
 // DCEL stores the state of the data structure and provides methods for linking of three sets of
-// objects: vertecies, edges and faces.
+// objects: vertecies, edges and faces. OuterFace optionally marks the unbounded face of the
+// subdivision and is nil unless set by the caller.
 type DCEL struct {
 	Vertices  []*Vertex
 	Faces     []*Face
 	HalfEdges []*HalfEdge
+	OuterFace *Face
+
+	journal *journal
 }
 
 // Vertex represents a node in the DCEL structure. Each vertex has 2D coordinates and a pointer
@@ -25,24 +29,32 @@ type Vertex struct {
 }
 
 // Face represents a subdivision of the plane. Each face has a pointer to one of the half edges
-// at its boundary. Faces can have user specified IDs and annotations.
+// at its outer boundary, and to one of the half edges of each hole inside it (inner components).
+// Faces can have user specified IDs and annotations. Faces created by NewFace or RebuildFaces also
+// keep a reference to the structure they belong to, for the methods that modify it.
 type Face struct {
-	HalfEdge *HalfEdge
-	ID       int64
-	Data     interface{}
+	HalfEdge        *HalfEdge
+	InnerComponents []*HalfEdge
+	ID              int64
+	Data            interface{}
+
+	dcel *DCEL
 }
 
 // HalfEdge represents one of the half-edges in an edge pair. Each half-edge has a pointer to its
 // target vertex (origin), the face to which it belongs, its twin edge (a reversed half-edge, pointing
 // to a neighbour face) and pointers to the next and previous half-edges at the boundary of its face.
-// Half-edges can also store user data.
+// Half-edges can also store user data. Constrained marks a half-edge as part of the boundary of a
+// shape, like the constraints of BuildConstrainedDelaunay, which is never flipped and which
+// ExteriorFaces does not cross. Both half-edges of a pair should be marked alike.
 type HalfEdge struct {
-	Target *Vertex
-	Face   *Face
-	Twin   *HalfEdge
-	Next   *HalfEdge
-	Prev   *HalfEdge
-	Data   interface{}
+	Target      *Vertex
+	Face        *Face
+	Twin        *HalfEdge
+	Next        *HalfEdge
+	Prev        *HalfEdge
+	Data        interface{}
+	Constrained bool
 }
 
 func (v *Vertex) String() string {
@@ -59,7 +71,12 @@ func (he *HalfEdge) String() string {
 		faceID = "#" + strconv.FormatInt(he.Face.ID, 10)
 	}
 
-	return fmt.Sprintf("{Edge %p; Target: %d,%d; Twin: %p; Face: %s}", he, he.Target.X, he.Target.Y, he.Twin, faceID)
+	target := "nil"
+	if he.Target != nil {
+		target = fmt.Sprintf("%d,%d", he.Target.X, he.Target.Y)
+	}
+
+	return fmt.Sprintf("{Edge %p; Target: %s; Twin: %p; Face: %s}", he, target, he.Twin, faceID)
 }
 
 // IsClosed returns true if both half-edges in the pair have a target vertex.
@@ -72,10 +89,26 @@ func NewDCEL() *DCEL {
 	return &DCEL{}
 }
 
+// NewDCELWithCapacity creates a new DCEL data structure with room for the given number of
+// vertices, faces and half-edges, so that building a large structure with the New* methods does
+// not need to grow the underlying slices repeatedly.
+func NewDCELWithCapacity(nv, nf, ne int) *DCEL {
+	return &DCEL{
+		Vertices:  make([]*Vertex, 0, nv),
+		Faces:     make([]*Face, 0, nf),
+		HalfEdges: make([]*HalfEdge, 0, ne),
+	}
+}
+
 // NewFace creates a new face and stores it in the DCEL structure.
 func (d *DCEL) NewFace() *Face {
-	face := &Face{}
+	face := &Face{dcel: d}
 	d.Faces = append(d.Faces, face)
+	if d.recording() {
+		d.record(func() {
+			d.remove(nil, nil, map[*Face]bool{face: true})
+		})
+	}
 	return face
 }
 
@@ -86,6 +119,11 @@ func (d *DCEL) NewVertex(x, y int) *Vertex {
 		Y: y,
 	}
 	d.Vertices = append(d.Vertices, vertex)
+	if d.recording() {
+		d.record(func() {
+			d.remove(map[*Vertex]bool{vertex: true}, nil, nil)
+		})
+	}
 	return vertex
 }
 
@@ -97,6 +135,31 @@ func (d *DCEL) NewHalfEdge(face *Face, vertex *Vertex, twin *HalfEdge) *HalfEdge
 		Twin:   twin,
 	}
 
+	if d.recording() {
+		oldFirst := face.HalfEdge
+		var oldPrev, oldPrevNext *HalfEdge
+		if oldFirst != nil {
+			oldPrev = oldFirst.Prev
+		}
+		if oldPrev != nil {
+			oldPrevNext = oldPrev.Next
+		}
+		linkVertex := vertex != nil && vertex.HalfEdge == nil
+		d.record(func() {
+			d.remove(nil, map[*HalfEdge]bool{halfEdge: true}, nil)
+			face.HalfEdge = oldFirst
+			if oldFirst != nil {
+				oldFirst.Prev = oldPrev
+			}
+			if oldPrev != nil {
+				oldPrev.Next = oldPrevNext
+			}
+			if linkVertex {
+				vertex.HalfEdge = nil
+			}
+		})
+	}
+
 	// Link new half-edge to the one pointed by the face counter-clockwise
 	if face.HalfEdge != nil {
 		halfEdge.Prev = face.HalfEdge.Prev
@@ -119,8 +182,40 @@ func (d *DCEL) NewHalfEdge(face *Face, vertex *Vertex, twin *HalfEdge) *HalfEdge
 
 // NewEdge creates a pair of half-edges, one of them starting at the given vertex.
 func (d *DCEL) NewEdge(face1, face2 *Face, vertex *Vertex) (*HalfEdge, *HalfEdge) {
+	if d.recording() {
+		defer d.beginOperation(false)()
+	}
 	halfEdge := d.NewHalfEdge(face1, vertex, nil)
 	twin := d.NewHalfEdge(face2, nil, halfEdge)
 	halfEdge.Twin = twin
 	return halfEdge, twin
 }
+
+// edges returns one half-edge of each edge pair in the structure, in the order they are stored.
+// Half-edges without a twin are returned too, as they represent an edge on their own.
+func (d *DCEL) edges() []*HalfEdge {
+	var edges []*HalfEdge
+	visited := make(map[*HalfEdge]bool)
+	for _, he := range d.HalfEdges {
+		if visited[he] {
+			continue
+		}
+		visited[he] = true
+		if he.Twin != nil {
+			visited[he.Twin] = true
+		}
+		edges = append(edges, he)
+	}
+	return edges
+}
+
+// boundedFaces returns all faces of the structure, except the outer face.
+func (d *DCEL) boundedFaces() []*Face {
+	faces := make([]*Face, 0, len(d.Faces))
+	for _, face := range d.Faces {
+		if face != d.OuterFace {
+			faces = append(faces, face)
+		}
+	}
+	return faces
+}
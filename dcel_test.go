@@ -0,0 +1,33 @@
+package dcel
+
+import "testing"
+
+// benchmarkEdges is the number of edges built by the construction benchmarks.
+const benchmarkEdges = 1000000
+
+// buildChain adds a chain of n edges between n+1 vertices to the structure, running between two
+// faces, with the New* methods.
+func buildChain(d *DCEL, n int) {
+	inner, outer := d.NewFace(), d.NewFace()
+	prev := d.NewVertex(0, 0)
+	for i := 1; i <= n; i++ {
+		v := d.NewVertex(i, i%2)
+		he, _ := d.NewEdge(inner, outer, v)
+		he.Twin.Target = prev
+		prev = v
+	}
+}
+
+func BenchmarkNewDCEL(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buildChain(NewDCEL(), benchmarkEdges)
+	}
+}
+
+func BenchmarkNewDCELWithCapacity(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buildChain(NewDCELWithCapacity(benchmarkEdges+1, 2, 2*benchmarkEdges), benchmarkEdges)
+	}
+}
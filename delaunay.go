@@ -0,0 +1,368 @@
+package dcel
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// BuildConstrainedDelaunay computes the constrained Delaunay triangulation of the given points.
+// Each constraint is a pair of indices into points, naming a segment that is forced to appear as
+// an edge of the triangulation. Only edges that are not constrained are flipped to restore the
+// Delaunay property, so with no constraints the result is the plain Delaunay triangulation.
+// The half-edges of the constraints are marked as Constrained.
+// A constraint that passes through another point is split at that point. Duplicate points are
+// merged into a single vertex.
+//
+// The bounded faces of the result are the counter-clockwise triangles, while the region outside
+// the convex hull of the points is stored as OuterFace. An error is returned if there are less
+// than three distinct points, if all points are collinear, or if two constraints cross each other.
+func BuildConstrainedDelaunay(points [][2]int, constraints [][2]int) (*DCEL, error) {
+	d := NewDCEL()
+	vertices := make([]*Vertex, len(points))
+	byCoords := make(map[[2]int]*Vertex)
+	for i, p := range points {
+		v, ok := byCoords[p]
+		if !ok {
+			v = d.NewVertex(p[0], p[1])
+			byCoords[p] = v
+		}
+		vertices[i] = v
+	}
+
+	segments := make([][2]*Vertex, 0, len(constraints))
+	for _, c := range constraints {
+		if c[0] < 0 || c[0] >= len(points) || c[1] < 0 || c[1] >= len(points) {
+			return nil, errors.New("constraint refers to a point that does not exist")
+		}
+		a, b := vertices[c[0]], vertices[c[1]]
+		if a == b {
+			return nil, errors.New("constraint has coinciding endpoints")
+		}
+		segments = append(segments, [2]*Vertex{a, b})
+	}
+	for i := range segments {
+		for j := i + 1; j < len(segments); j++ {
+			if segmentsCross(segments[i][0], segments[i][1], segments[j][0], segments[j][1]) {
+				return nil, errors.New("constraints cross each other")
+			}
+		}
+	}
+
+	if err := d.triangulate(); err != nil {
+		return nil, err
+	}
+	for _, s := range segments {
+		if err := d.insertConstraint(s[0], s[1]); err != nil {
+			return nil, err
+		}
+	}
+	d.legalize(d.InteriorEdges())
+	return d, nil
+}
+
+// triangulate builds an arbitrary triangulation of the convex hull of the vertices in the
+// structure, by sorting them lexicographically and connecting every next vertex to the part of
+// the current hull that is visible from it.
+func (d *DCEL) triangulate() error {
+	sorted := make([]*Vertex, len(d.Vertices))
+	copy(sorted, d.Vertices)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].X != sorted[j].X {
+			return sorted[i].X < sorted[j].X
+		}
+		return sorted[i].Y < sorted[j].Y
+	})
+	if len(sorted) < 3 {
+		return errors.New("at least three distinct points are required")
+	}
+
+	// Find the first vertex that is not collinear with the ones before it
+	k := 2
+	for k < len(sorted) && orient(sorted[0], sorted[1], sorted[k]) == 0 {
+		k++
+	}
+	if k == len(sorted) {
+		return errors.New("all points are collinear")
+	}
+
+	d.OuterFace = d.NewFace()
+	d.newHullTriangle(sorted[k-2], sorted[k-1], sorted[k])
+	// The remaining collinear vertices lie on the extension of the first hull edge, in decreasing
+	// order, so each of them is outside the hull of the vertices inserted before it.
+	for i := k - 3; i >= 0; i-- {
+		d.insertOutsideHull(sorted[i])
+	}
+	for i := k + 1; i < len(sorted); i++ {
+		d.insertOutsideHull(sorted[i])
+	}
+	return nil
+}
+
+// addHalfEdge creates a half-edge with the given face and target and stores it in the structure,
+// without linking it to other half-edges.
+func (d *DCEL) addHalfEdge(face *Face, target *Vertex) *HalfEdge {
+	he := &HalfEdge{Face: face, Target: target}
+	if target != nil && target.HalfEdge == nil {
+		target.HalfEdge = he
+	}
+	d.HalfEdges = append(d.HalfEdges, he)
+	return he
+}
+
+// linkCycle links the given half-edges into a closed Next/Prev cycle in the given order.
+func linkCycle(edges ...*HalfEdge) {
+	for i, he := range edges {
+		next := edges[(i+1)%len(edges)]
+		he.Next = next
+		next.Prev = he
+	}
+}
+
+// newHullTriangle creates the initial triangle of the triangulation and links it to the outer face.
+func (d *DCEL) newHullTriangle(a, b, c *Vertex) {
+	if orient(a, b, c) < 0 {
+		b, c = c, b
+	}
+	face := d.NewFace()
+	ab, bc, ca := d.addHalfEdge(face, b), d.addHalfEdge(face, c), d.addHalfEdge(face, a)
+	ba, cb, ac := d.addHalfEdge(d.OuterFace, a), d.addHalfEdge(d.OuterFace, b), d.addHalfEdge(d.OuterFace, c)
+	ab.Twin, ba.Twin = ba, ab
+	bc.Twin, cb.Twin = cb, bc
+	ca.Twin, ac.Twin = ac, ca
+	linkCycle(ab, bc, ca)
+	linkCycle(ba, ac, cb)
+	face.HalfEdge = ab
+	d.OuterFace.HalfEdge = ba
+}
+
+// insertOutsideHull adds a vertex lying outside the current triangulation by connecting it to all
+// hull edges visible from it, creating one new triangle per visible edge.
+func (d *DCEL) insertOutsideHull(p *Vertex) {
+	visible := func(he *HalfEdge) bool {
+		return orient(he.Origin(), he.Target, p) > 0
+	}
+
+	// Find the first half-edge of the visible chain on the outer boundary
+	first := d.OuterFace.HalfEdge
+	for !visible(first) {
+		first = first.Next
+	}
+	for visible(first.Prev) {
+		first = first.Prev
+	}
+	before := first.Prev
+
+	var chain []*HalfEdge
+	for he := first; visible(he); he = he.Next {
+		chain = append(chain, he)
+	}
+	after := chain[len(chain)-1].Next
+
+	var prevOut *HalfEdge
+	var firstIn *HalfEdge
+	for _, he := range chain {
+		face := d.NewFace()
+		out := d.addHalfEdge(face, p)
+		in := d.addHalfEdge(face, he.Origin())
+		he.Face = face
+		linkCycle(he, out, in)
+		face.HalfEdge = he
+		if prevOut != nil {
+			prevOut.Twin, in.Twin = in, prevOut
+		} else {
+			firstIn = in
+		}
+		prevOut = out
+	}
+
+	toP := d.addHalfEdge(d.OuterFace, p)
+	fromP := d.addHalfEdge(d.OuterFace, chain[len(chain)-1].Target)
+	toP.Twin, firstIn.Twin = firstIn, toP
+	fromP.Twin, prevOut.Twin = prevOut, fromP
+	before.Next, toP.Prev = toP, before
+	toP.Next, fromP.Prev = fromP, toP
+	fromP.Next, after.Prev = after, fromP
+	d.OuterFace.HalfEdge = toP
+}
+
+// isDelaunay returns true if the edge of he satisfies the local Delaunay condition, i.e. if the
+// vertex opposite to it in the triangle of its twin does not lie inside the circumcircle of the
+// triangle of he.
+func isDelaunay(he *HalfEdge) bool {
+	return inCircle(he.Origin(), he.Target, he.Next.Target, he.Twin.Next.Target) <= 0
+}
+
+// legalize flips edges, starting with the given ones, until every edge that is not constrained
+// satisfies the local Delaunay condition. Only edges between two triangles are flipped.
+func (d *DCEL) legalize(stack []*HalfEdge) {
+	for len(stack) > 0 {
+		he := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if he.Constrained || he.Twin == nil || he.Face == d.OuterFace || he.Twin.Face == d.OuterFace ||
+			len(he.Face.boundary()) != 3 || len(he.Twin.Face.boundary()) != 3 || isDelaunay(he) {
+			continue
+		}
+		d.flipEdge(he)
+		for _, e := range []*HalfEdge{he.Next, he.Prev, he.Twin.Next, he.Twin.Prev} {
+			if e.Twin.Face != d.OuterFace {
+				stack = append(stack, e)
+			}
+		}
+	}
+}
+
+// flipEdge replaces the diagonal he of the quadrilateral formed by the two triangles adjacent to
+// it with the opposite diagonal. The caller must make sure that both faces are triangles and that
+// the quadrilateral is strictly convex.
+func (d *DCEL) flipEdge(he *HalfEdge) {
+	tw := he.Twin
+	a, b := tw.Target, he.Target
+	e1, e2 := he.Next, he.Prev // b->c, c->a
+	e3, e4 := tw.Next, tw.Prev // a->d, d->b
+	c, dv := e1.Target, e3.Target
+	f1, f2 := he.Face, tw.Face
+
+	he.Target, tw.Target = c, dv
+	linkCycle(e3, he, e2)
+	linkCycle(e4, e1, tw)
+	e3.Face, e1.Face = f1, f2
+	f1.HalfEdge, f2.HalfEdge = he, tw
+
+	if a.HalfEdge == tw {
+		a.HalfEdge = e2
+	}
+	if b.HalfEdge == he {
+		b.HalfEdge = e4
+	}
+}
+
+// insertConstraint makes sure that the segment between a and b is an edge of the triangulation,
+// by flipping the edges crossing it, and marks both of its half-edges as constrained.
+func (d *DCEL) insertConstraint(a, b *Vertex) error {
+	for _, v := range d.Vertices {
+		if onSegment(v, a, b) {
+			if err := d.insertConstraint(a, v); err != nil {
+				return err
+			}
+			return d.insertConstraint(v, b)
+		}
+	}
+
+	var crossing []*HalfEdge
+	for _, he := range d.InteriorEdges() {
+		if segmentsCross(a, b, he.Origin(), he.Target) {
+			if he.Constrained {
+				return errors.New("constraints cross each other")
+			}
+			crossing = append(crossing, he)
+		}
+	}
+
+	// Flip crossing edges until none is left, postponing the ones whose quadrilateral is not
+	// convex (Sloan's algorithm).
+	for len(crossing) > 0 {
+		he := crossing[0]
+		crossing = crossing[1:]
+		c, dv := he.Next.Target, he.Twin.Next.Target
+		if !segmentsCross(he.Origin(), he.Target, c, dv) {
+			crossing = append(crossing, he)
+			continue
+		}
+		d.flipEdge(he)
+		if segmentsCross(a, b, he.Origin(), he.Target) {
+			crossing = append(crossing, he)
+		}
+	}
+
+	for _, he := range d.HalfEdges {
+		if he.Target == b && he.Origin() == a {
+			he.Constrained, he.Twin.Constrained = true, true
+		}
+	}
+	return nil
+}
+
+// InsertCircumcenter inserts a new vertex at the circumcenter of the triangular face, rounded to
+// integer coordinates, and restores the Delaunay property around it by flipping edges, as done
+// when refining a mesh with Ruppert's algorithm. The triangle containing the new vertex is split
+// into three, or if the vertex falls on an edge, the triangles on both sides of the edge are split
+// into two. The new faces get no ID or Data. Constrained edges and the edges on the outer boundary
+// are never flipped, and the pieces of a split Constrained edge stay Constrained.
+// An error is returned if the face is not a non-degenerate triangle, if the circumcenter lies
+// outside of the bounded faces, on the outer boundary or on an edge shared with a face that is not
+// a triangle, or if it coincides with an existing vertex.
+func (d *DCEL) InsertCircumcenter(f *Face) (*Vertex, error) {
+	cx, cy, _, err := f.Circumcircle()
+	if err != nil {
+		return nil, err
+	}
+	x, y := int(math.Round(cx)), int(math.Round(cy))
+	var container *Face
+	for _, g := range d.boundedFaces() {
+		if len(g.boundary()) == 3 && g.Classify(x, y) >= 0 {
+			container = g
+			break
+		}
+	}
+	if container == nil {
+		return nil, errors.New("circumcenter lies outside of the mesh")
+	}
+	p := &Vertex{X: x, Y: y}
+	var split *HalfEdge
+	for _, he := range container.boundary() {
+		if he.Target.X == x && he.Target.Y == y {
+			return nil, errors.New("circumcenter coincides with an existing vertex")
+		}
+		if onSegment(p, he.Origin(), he.Target) {
+			split = he
+		}
+	}
+	if split != nil {
+		switch twin := split.Twin; {
+		case twin == nil || twin.Face == nil || twin.Face == d.OuterFace:
+			return nil, errors.New("circumcenter lies on the outer boundary of the mesh")
+		case len(twin.Face.boundary()) != 3:
+			return nil, errors.New("circumcenter lies on an edge of a face that is not a triangle")
+		}
+	}
+
+	defer d.beginOperation(true)()
+	v := d.NewVertex(x, y)
+	if split == nil {
+		d.splitTriangle(container, v)
+	} else {
+		twin := split.Twin
+		d.splitEdgeAt(split, v)
+		d.splitFace(split, split.Next.Next)
+		d.splitFace(twin, twin.Next.Next)
+	}
+
+	var stack []*HalfEdge
+	for _, he := range v.incoming() {
+		stack = append(stack, he.Next.Next)
+	}
+	d.legalize(stack)
+	return v, nil
+}
+
+// splitTriangle connects the vertex, which must lie strictly inside the triangular face, to the
+// three vertices of the face, splitting it into three triangles. The face is reused for the first
+// of them.
+func (d *DCEL) splitTriangle(f *Face, v *Vertex) {
+	edges := f.boundary()
+	faces := []*Face{f, d.NewFace(), d.NewFace()}
+	toV := make([]*HalfEdge, 3)
+	fromV := make([]*HalfEdge, 3)
+	for i, he := range edges {
+		toV[i] = d.addHalfEdge(faces[i], v)
+		fromV[i] = d.addHalfEdge(faces[i], he.Origin())
+	}
+	for i, he := range edges {
+		next := (i + 1) % 3
+		toV[i].Twin, fromV[next].Twin = fromV[next], toV[i]
+		he.Face = faces[i]
+		linkCycle(he, toV[i], fromV[i])
+		faces[i].HalfEdge = he
+	}
+}
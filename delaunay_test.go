@@ -0,0 +1,23 @@
+package dcel
+
+import "testing"
+
+func TestInsertCircumcenterNextToPolygon(t *testing.T) {
+	// The circumcenter (5, 5) of the right triangle lies on its hypotenuse, which it shares with a
+	// quadrilateral
+	d, err := BuildArrangement([][4]int{
+		{0, 0, 10, 0}, {10, 0, 0, 10}, {0, 10, 0, 0},
+		{10, 0, 15, 5}, {15, 5, 10, 10}, {10, 10, 0, 10},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	triangle := faceAt(t, d, 1, 1)
+	before := len(d.Vertices)
+	if v, err := d.InsertCircumcenter(triangle); err == nil {
+		t.Errorf("InsertCircumcenter() = %v, want an error", v)
+	}
+	if len(d.Vertices) != before {
+		t.Errorf("structure has %d vertices, want %d", len(d.Vertices), before)
+	}
+}
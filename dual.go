@@ -0,0 +1,171 @@
+package dcel
+
+import (
+	"errors"
+	"math"
+)
+
+// Dual returns a new DCEL representing the dual of the subdivision. Each bounded face becomes a
+// vertex placed at its centroid (rounded to integer coordinates), each edge separating two
+// distinct bounded faces becomes an edge connecting their dual vertices, and each vertex whose
+// incident faces are all bounded becomes a face of the dual. The Data field of every dual vertex
+// and dual face holds the original face and vertex it was created for.
+//
+// The outer face is omitted from the dual: it gets no vertex, and the edges on the outer boundary
+// have no dual edge. Instead, the dual faces that would have surrounded the vertices on the outer
+// boundary are merged into the unbounded face of the dual, which is stored as its OuterFace and
+// has nil Data. A dual half-edge points from the face to the right of its original half-edge to
+// the face to the left of it.
+func (d *DCEL) Dual() *DCEL {
+	dual := NewDCEL()
+	vertices := make(map[*Face]*Vertex)
+	for _, face := range d.boundedFaces() {
+		if face.HalfEdge == nil {
+			continue
+		}
+		x, y := face.Centroid()
+		v := dual.NewVertex(int(math.Round(x)), int(math.Round(y)))
+		v.Data = face
+		vertices[face] = v
+	}
+
+	hasDual := func(he *HalfEdge) bool {
+		return he.Twin != nil && vertices[he.Face] != nil && vertices[he.Twin.Face] != nil &&
+			he.Face != he.Twin.Face
+	}
+	rotated := make(map[*HalfEdge]*HalfEdge)
+	original := make(map[*HalfEdge]*HalfEdge)
+	for _, he := range d.HalfEdges {
+		if hasDual(he) {
+			r := dual.addHalfEdge(nil, vertices[he.Face])
+			rotated[he], original[r] = r, he
+		}
+	}
+	for he, r := range rotated {
+		r.Twin = rotated[he.Twin]
+		// The next dual half-edge leaves the dual vertex of he.Face, crossing the closest edge
+		// before he at the boundary of that face that has a dual.
+		p := he.Prev
+		for p != he && !hasDual(p) {
+			p = p.Prev
+		}
+		if p == he {
+			r.Next = r.Twin
+		} else {
+			r.Next = rotated[p.Twin]
+		}
+		r.Next.Prev = r
+	}
+
+	for _, he := range d.HalfEdges {
+		start := rotated[he]
+		if start == nil || start.Face != nil {
+			continue
+		}
+		face := dual.NewFace()
+		face.HalfEdge = start
+		// A cycle is the ring around a vertex if all its dual half-edges cross edges leaving it
+		origin, ring := he.Origin(), true
+		for _, r := range face.HalfEdges() {
+			r.Face = face
+			ring = ring && original[r].Origin() == origin
+		}
+		if ring {
+			face.Data = origin
+		} else if dual.OuterFace == nil {
+			dual.OuterFace = face
+		}
+	}
+	return dual
+}
+
+// DualAdjacencyMatrix returns the adjacency matrix of the dual graph, in which two faces are
+// adjacent if they share an edge, along with the faces the rows and columns stand for. Entry
+// [i][j] is the number of edges shared by faces[i] and faces[j], so the matrix is symmetric, and
+// its diagonal is zero as edges with the same face on both sides are not counted. The faces are
+// the bounded ones, listed in the order they are stored, so the edges on the outer boundary are
+// not counted either; use DualAdjacencyMatrixWithOuter to include the OuterFace.
+func (d *DCEL) DualAdjacencyMatrix() ([][]int, []*Face) {
+	return d.dualAdjacency(d.boundedFaces())
+}
+
+// DualAdjacencyMatrixWithOuter returns the adjacency matrix of the dual graph like
+// DualAdjacencyMatrix, but includes the OuterFace, in its place among the stored faces.
+func (d *DCEL) DualAdjacencyMatrixWithOuter() ([][]int, []*Face) {
+	return d.dualAdjacency(append([]*Face(nil), d.Faces...))
+}
+
+// dualAdjacency returns the adjacency matrix of the dual graph restricted to the given faces.
+func (d *DCEL) dualAdjacency(faces []*Face) ([][]int, []*Face) {
+	index := make(map[*Face]int, len(faces))
+	for i, f := range faces {
+		index[f] = i
+	}
+	matrix := make([][]int, len(faces))
+	for i := range matrix {
+		matrix[i] = make([]int, len(faces))
+	}
+	for _, he := range d.edges() {
+		if he.Twin == nil || he.Face == he.Twin.Face {
+			continue
+		}
+		i, ok1 := index[he.Face]
+		j, ok2 := index[he.Twin.Face]
+		if ok1 && ok2 {
+			matrix[i][j]++
+			matrix[j][i]++
+		}
+	}
+	return matrix, faces
+}
+
+// TriangulationDualTree returns the dual tree of a triangulated simple polygon, mapping every
+// bounded face to the faces it shares an edge with, in the order they are stored. The adjacency is
+// taken from DualAdjacencyMatrix, which leaves out the OuterFace, so the edges on the outer
+// boundary do not count. As the dual graph of a triangulation of a simple polygon is a tree, an
+// error is returned if a bounded face is not a triangle, if two triangles share more than one
+// edge, or if the dual graph contains a cycle, which happens around interior vertices or holes, or
+// is not connected. A structure without bounded faces has an empty tree.
+func (d *DCEL) TriangulationDualTree() (map[*Face][]*Face, error) {
+	matrix, faces := d.DualAdjacencyMatrix()
+	for _, f := range faces {
+		if f.Degree() != 3 {
+			return nil, errors.New("face is not a triangle")
+		}
+	}
+
+	tree := make(map[*Face][]*Face, len(faces))
+	edges := 0
+	for i, f := range faces {
+		tree[f] = nil
+		for j, shared := range matrix[i] {
+			if shared > 1 {
+				return nil, errors.New("triangles share more than one edge")
+			}
+			if shared == 1 {
+				tree[f] = append(tree[f], faces[j])
+				if j > i {
+					edges++
+				}
+			}
+		}
+	}
+	if len(faces) == 0 {
+		return tree, nil
+	}
+	if edges != len(faces)-1 {
+		return nil, errors.New("dual graph contains a cycle")
+	}
+
+	visited := make(map[*Face]bool, len(faces))
+	d.walkFaces(faces[0], func(he *HalfEdge) bool {
+		return he.Twin.Face != d.OuterFace
+	}, func(f *Face) bool {
+		visited[f] = true
+		return true
+	})
+	if len(visited) != len(faces) {
+		return nil, errors.New("dual graph is not connected")
+	}
+	return tree, nil
+}
@@ -0,0 +1,607 @@
+package dcel
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// SplitEdge splits the edge of he at the point with the given coordinates by inserting a new
+// vertex there, and returns the new vertex. The half-edge keeps its origin and now ends at the new
+// vertex, while a new half-edge is linked after it to continue to the original target. The twin
+// half-edge, if any, is split the same way, so both faces stay closed. The new half-edges get no
+// Data, but are Constrained if the half-edges they continue are.
+func (d *DCEL) SplitEdge(he *HalfEdge, x, y int) *Vertex {
+	defer d.beginOperation(true)()
+	vertex := d.NewVertex(x, y)
+	d.splitEdgeAt(he, vertex)
+	return vertex
+}
+
+// SplitEdgeWithData splits the edge of he at the point (x, y) like SplitEdge, but derives the Data
+// of the resulting half-edges from the Data of the half-edges they were cut from. The split
+// function is called for each of the resulting half-edges, with the original Data and the
+// fraction t of the original half-edge covered by the piece, measured along the edge, so the two
+// pieces of a half-edge get t and 1 - t. This allows to preserve attributes like costs, by
+// scaling them with t, or widths, by copying them. The new vertex is returned.
+func (d *DCEL) SplitEdgeWithData(he *HalfEdge, x, y int, split func(orig interface{}, t float64) interface{}) *Vertex {
+	defer d.beginOperation(true)()
+	origin := he.Origin()
+	t := 0.0
+	if length := he.Length(); length > 0 && origin != nil {
+		t = math.Hypot(float64(x-origin.X), float64(y-origin.Y)) / length
+	}
+	data, twin := he.Data, he.Twin
+	var twinData interface{}
+	if twin != nil {
+		twinData = twin.Data
+	}
+
+	vertex := d.SplitEdge(he, x, y)
+	he.Data, he.Next.Data = split(data, t), split(data, 1-t)
+	if twin != nil {
+		// The twin runs the other way, so its first piece is the one next to the target of he
+		twin.Data, twin.Next.Data = split(twinData, 1-t), split(twinData, t)
+	}
+	return vertex
+}
+
+// splitEdgeAt splits the edge of he at the given vertex, which must already be in the structure.
+func (d *DCEL) splitEdgeAt(he *HalfEdge, vertex *Vertex) {
+	origin, target := he.Origin(), he.Target
+
+	next := d.addHalfEdge(he.Face, target)
+	next.Constrained = he.Constrained
+	next.Prev, next.Next = he, he.Next
+	if he.Next != nil {
+		he.Next.Prev = next
+	}
+	he.Next = next
+	he.Target = vertex
+	if target != nil && target.HalfEdge == he {
+		target.HalfEdge = next
+	}
+
+	if twin := he.Twin; twin != nil {
+		twinNext := d.addHalfEdge(twin.Face, origin)
+		twinNext.Constrained = twin.Constrained
+		twinNext.Prev, twinNext.Next = twin, twin.Next
+		if twin.Next != nil {
+			twin.Next.Prev = twinNext
+		}
+		twin.Next = twinNext
+		twin.Target = vertex
+		if origin != nil && origin.HalfEdge == twin {
+			origin.HalfEdge = twinNext
+		}
+		he.Twin, twinNext.Twin = twinNext, he
+		next.Twin, twin.Twin = twin, next
+	}
+
+	if vertex.HalfEdge == nil {
+		vertex.HalfEdge = he
+	}
+}
+
+// remove deletes the given vertices, half-edges and faces from the slices of the structure. Any
+// pointers to them from the remaining objects must be updated by the caller.
+func (d *DCEL) remove(vertices map[*Vertex]bool, halfEdges map[*HalfEdge]bool, faces map[*Face]bool) {
+	if len(vertices) > 0 {
+		kept := d.Vertices[:0]
+		for _, v := range d.Vertices {
+			if !vertices[v] {
+				kept = append(kept, v)
+			}
+		}
+		d.Vertices = kept
+	}
+	if len(halfEdges) > 0 {
+		kept := d.HalfEdges[:0]
+		for _, he := range d.HalfEdges {
+			if !halfEdges[he] {
+				kept = append(kept, he)
+			}
+		}
+		d.HalfEdges = kept
+	}
+	if len(faces) > 0 {
+		kept := d.Faces[:0]
+		for _, f := range d.Faces {
+			if !faces[f] {
+				kept = append(kept, f)
+			}
+		}
+		d.Faces = kept
+	}
+}
+
+// dissolveVertex removes a vertex of degree two by merging its two edges into a single edge
+// between its neighbours. The half-edges targeting the vertex are kept and extended, while the two
+// ones leaving it are unlinked and returned, so the caller can remove them together with the
+// vertex. Nothing is changed and nil is returned if the vertex does not have exactly two incident
+// edges, if both edges lead to the same neighbour, or if it lies on a face with three edges or less.
+func dissolveVertex(v *Vertex) []*HalfEdge {
+	edges := v.incoming()
+	if len(edges) != 2 {
+		return nil
+	}
+	e1, e2 := edges[0], edges[1]
+	o1, o2 := e1.Twin, e2.Twin
+	if o1 == nil || o2 == nil || e1.Next != o2 || e2.Next != o1 {
+		return nil
+	}
+	a, b := e1.Origin(), e2.Origin()
+	if a == nil || b == nil || a == b || len(e1.Face.HalfEdges()) <= 3 || len(e2.Face.HalfEdges()) <= 3 {
+		return nil
+	}
+
+	// e1 now leads from a to b, replacing o2, and e2 from b to a, replacing o1
+	e1.Target, e2.Target = b, a
+	e1.Next, o2.Next.Prev = o2.Next, e1
+	e2.Next, o1.Next.Prev = o1.Next, e2
+	e1.Twin, e2.Twin = e2, e1
+	replace := func(f *Face, old, new *HalfEdge) {
+		if f.HalfEdge == old {
+			f.HalfEdge = new
+		}
+		for i, hole := range f.InnerComponents {
+			if hole == old {
+				f.InnerComponents[i] = new
+			}
+		}
+	}
+	replace(e1.Face, o2, e1)
+	replace(e2.Face, o1, e2)
+	if b.HalfEdge == o2 {
+		b.HalfEdge = e1
+	}
+	if a.HalfEdge == o1 {
+		a.HalfEdge = e2
+	}
+	v.HalfEdge = nil
+	return []*HalfEdge{o1, o2}
+}
+
+// SimplifyCollinear removes the vertices of degree two that lie within epsilon of the line
+// through their two neighbours, merging their two edges into one. Vertices are kept if removing
+// them would leave a face with less than three edges.
+func (d *DCEL) SimplifyCollinear(epsilon float64) {
+	defer d.beginOperation(true)()
+	d.dissolveVertices(func(v, a, b *Vertex) bool {
+		return distanceToLine(v, a, b) <= epsilon
+	})
+}
+
+// RemoveDegree2Vertices removes the vertices with exactly two incident edges, merging their two
+// edges into one, so that only the vertices where edges branch or end are left. If collinearOnly
+// is true, only the vertices lying exactly on the line through their two neighbours are removed,
+// so the geometry does not change. Otherwise the removal is purely topological and the merged
+// edges become straight segments between the neighbours. Vertices are kept if removing them would
+// leave a face with less than three edges.
+func (d *DCEL) RemoveDegree2Vertices(collinearOnly bool) {
+	defer d.beginOperation(true)()
+	d.dissolveVertices(func(v, a, b *Vertex) bool {
+		return !collinearOnly || orient(a, b, v) == 0
+	})
+}
+
+// dissolveVertices removes the vertices of degree two for which dissolve, called with the vertex
+// and its two neighbours, returns true, merging their two edges into one with dissolveVertex.
+func (d *DCEL) dissolveVertices(dissolve func(v, a, b *Vertex) bool) {
+	removedVertices := make(map[*Vertex]bool)
+	removedEdges := make(map[*HalfEdge]bool)
+	for _, v := range d.Vertices {
+		edges := v.incoming()
+		if len(edges) != 2 {
+			continue
+		}
+		a, b := edges[0].Origin(), edges[1].Origin()
+		if a == nil || b == nil || !dissolve(v, a, b) {
+			continue
+		}
+		if removed := dissolveVertex(v); removed != nil {
+			removedVertices[v] = true
+			for _, he := range removed {
+				removedEdges[he] = true
+			}
+		}
+	}
+	d.remove(removedVertices, removedEdges, nil)
+}
+
+// InsertVertexSnapped inserts a vertex on the edge closest to the point (x, y), if that edge is
+// within threshold distance of the point. The point is projected onto the edge, rounded to integer
+// coordinates, and the edge is split there with SplitEdge. If a vertex of the structure, such as
+// an endpoint of the edge, already lies at the rounded point, no edge is split and that vertex is
+// returned instead. If no edge is close enough, or if the rounded point does not lie on the edge,
+// so that splitting there would bend the edge, (nil, false) is returned and nothing is changed.
+func (d *DCEL) InsertVertexSnapped(x, y, threshold int) (*Vertex, bool) {
+	defer d.beginOperation(true)()
+	var nearest *HalfEdge
+	nearestDist := float64(threshold)
+	px, py := float64(x), float64(y)
+	for _, he := range d.edges() {
+		origin := he.Origin()
+		if origin == nil || he.Target == nil {
+			continue
+		}
+		cx, cy := closestOnSegment(px, py, origin, he.Target)
+		if dist := math.Hypot(px-cx, py-cy); dist <= nearestDist {
+			nearest, nearestDist = he, dist
+		}
+	}
+	if nearest == nil {
+		return nil, false
+	}
+
+	cx, cy := closestOnSegment(px, py, nearest.Origin(), nearest.Target)
+	snapped := &Vertex{X: int(math.Round(cx)), Y: int(math.Round(cy))}
+	for _, v := range d.Vertices {
+		if v.X == snapped.X && v.Y == snapped.Y {
+			return v, true
+		}
+	}
+	if !onSegment(snapped, nearest.Origin(), nearest.Target) {
+		return nil, false
+	}
+	return d.SplitEdge(nearest, snapped.X, snapped.Y), true
+}
+
+// RemoveEdge removes the edge of he, i.e. the half-edge and its twin, from the structure. If the
+// two sides of the edge belong to different faces, the faces are merged into one: the outer face
+// always survives, otherwise the face of he is kept and the face of its twin is removed. The
+// surviving face keeps its own Data and takes over the InnerComponents of the removed one, and if
+// it lay in a hole of the removed face, also its outer boundary. If both sides belong to the same
+// face, its boundary may be split into separate cycles, of which the one enclosing the largest
+// area stays its boundary while the others become InnerComponents. Vertices left without edges
+// stay in the structure. An error is returned if the half-edge has no twin or if the Next
+// and Prev pointers around the edge are not set.
+func (d *DCEL) RemoveEdge(he *HalfEdge) error {
+	return d.RemoveEdgeWithMerge(he, nil)
+}
+
+// RemoveEdgeWithMerge removes the edge of he like RemoveEdge, but when two bounded faces are merged
+// it stores the result of mergeData, called with the Data of the surviving face and the Data of
+// the removed face, on the surviving face. A nil mergeData keeps the Data of the surviving face.
+func (d *DCEL) RemoveEdgeWithMerge(he *HalfEdge, mergeData func(a, b interface{}) interface{}) error {
+	tw := he.Twin
+	if tw == nil || he.Next == nil || he.Prev == nil || tw.Next == nil || tw.Prev == nil {
+		return errors.New("edge is not linked with a twin and neighbouring half-edges")
+	}
+	defer d.beginOperation(true)()
+
+	// The half-edges of the cycles through the edge, whose references in the faces are replaced
+	old := make(map[*HalfEdge]bool)
+	for _, e := range append(cycle(he), cycle(tw)...) {
+		old[e] = true
+	}
+
+	link := func(a, b *HalfEdge) {
+		a.Next, b.Prev = b, a
+	}
+	switch {
+	case he.Next == tw && tw.Next == he:
+	case he.Next == tw:
+		link(he.Prev, tw.Next)
+	case tw.Next == he:
+		link(tw.Prev, he.Next)
+	default:
+		link(he.Prev, tw.Next)
+		link(tw.Prev, he.Next)
+	}
+
+	// The cycles left in place of the old ones: one, or two if the boundary was split
+	var cycles [][]*HalfEdge
+	seen := make(map[*HalfEdge]bool)
+	for _, e := range []*HalfEdge{he.Next, tw.Next} {
+		if e == he || e == tw || seen[e] {
+			continue
+		}
+		c := cycle(e)
+		for _, x := range c {
+			seen[x] = true
+		}
+		cycles = append(cycles, c)
+	}
+
+	survivor, removed := he.Face, tw.Face
+	if removed == d.OuterFace {
+		survivor, removed = removed, survivor
+	}
+	merged := survivor != removed && removed != nil
+	if survivor != nil {
+		faces := []*Face{survivor}
+		if merged {
+			faces = append(faces, removed)
+		}
+		var holes []*HalfEdge
+		for _, f := range faces {
+			for _, hole := range f.InnerComponents {
+				if !old[hole] {
+					holes = append(holes, hole)
+				}
+			}
+		}
+		outer := survivor.HalfEdge
+		if outer != nil && old[outer] {
+			if merged && removed.HalfEdge != nil && !old[removed.HalfEdge] {
+				// The face of he lay in a hole of the face of tw, whose boundary it takes over
+				outer = removed.HalfEdge
+			} else {
+				// The boundary was merged or split, and the cycle enclosing the others is kept
+				outer = nil
+				best := 0
+				for i, c := range cycles {
+					if outer == nil || ringArea(c) > ringArea(cycles[best]) {
+						outer, best = c[0], i
+					}
+				}
+				if outer != nil {
+					cycles = append(cycles[:best], cycles[best+1:]...)
+				}
+			}
+		}
+		for _, c := range cycles {
+			holes = append(holes, c[0])
+		}
+		survivor.HalfEdge, survivor.InnerComponents = outer, holes
+	}
+
+	if merged {
+		if mergeData != nil && survivor != d.OuterFace && survivor != nil {
+			survivor.Data = mergeData(survivor.Data, removed.Data)
+		}
+		for _, e := range d.HalfEdges {
+			if e.Face == removed {
+				e.Face = survivor
+			}
+		}
+		d.remove(nil, nil, map[*Face]bool{removed: true})
+	}
+
+	if a := tw.Target; a != nil && a.HalfEdge == tw {
+		a.HalfEdge = nil
+		if he.Prev != tw {
+			a.HalfEdge = he.Prev
+		}
+	}
+	if b := he.Target; b != nil && b.HalfEdge == he {
+		b.HalfEdge = nil
+		if tw.Prev != he {
+			b.HalfEdge = tw.Prev
+		}
+	}
+	d.remove(nil, map[*HalfEdge]bool{he: true, tw: true}, nil)
+	return nil
+}
+
+// CanMerge returns true if removing the edge shared by two distinct faces with RemoveEdge would
+// leave a face with a simple boundary. That is the case if the faces share exactly one edge and
+// have no other vertex in common than its two endpoints, counting the boundaries of their holes.
+// Faces sharing several edges, which would leave dangling edges or separate boundary cycles
+// behind, or touching at another vertex, at which the merged boundary would pinch, can not be
+// merged that way.
+func (d *DCEL) CanMerge(f1, f2 *Face) bool {
+	if f1 == nil || f2 == nil || f1 == f2 {
+		return false
+	}
+	var shared *HalfEdge
+	vertices := make(map[*Vertex]bool)
+	for _, he := range d.HalfEdges {
+		if he.Face != f1 {
+			continue
+		}
+		if he.Twin != nil && he.Twin.Face == f2 {
+			if shared != nil {
+				return false
+			}
+			shared = he
+		}
+		vertices[he.Target] = true
+	}
+	if shared == nil {
+		return false
+	}
+	for _, he := range d.HalfEdges {
+		if he.Face == f2 && vertices[he.Target] && he.Target != shared.Target && he.Target != shared.Twin.Target {
+			return false
+		}
+	}
+	return true
+}
+
+// WeldVertices merges vertices that lie within tolerance of each other into a single vertex, and
+// returns the number of vertices removed that way. Vertices are merged transitively, so a chain of
+// vertices, each one close to the next, becomes one vertex, which is the one stored first and
+// keeps its coordinates and Data. Half-edges targeting a removed vertex are redirected to the one
+// it was merged into. Edges collapsed to a single point by the merge are removed, and so are faces
+// left with no edges, while faces left with two edges are removed too, by making their two
+// neighbours twins of each other.
+func (d *DCEL) WeldVertices(tolerance int) int {
+	defer d.beginOperation(true)()
+	sorted := make([]*Vertex, len(d.Vertices))
+	copy(sorted, d.Vertices)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].X < sorted[j].X })
+	order := make(map[*Vertex]int, len(d.Vertices))
+	for i, v := range d.Vertices {
+		order[v] = i
+	}
+
+	parent := make(map[*Vertex]*Vertex)
+	var find func(v *Vertex) *Vertex
+	find = func(v *Vertex) *Vertex {
+		p, ok := parent[v]
+		if !ok {
+			return v
+		}
+		root := find(p)
+		parent[v] = root
+		return root
+	}
+	limit := float64(tolerance)
+	for i, v := range sorted {
+		for _, w := range sorted[i+1:] {
+			if w.X-v.X > tolerance {
+				break
+			}
+			if distance(v, w) > limit {
+				continue
+			}
+			a, b := find(v), find(w)
+			if a == b {
+				continue
+			}
+			if order[b] < order[a] {
+				a, b = b, a
+			}
+			parent[b] = a
+		}
+	}
+	if len(parent) == 0 {
+		return 0
+	}
+
+	removedVertices := make(map[*Vertex]bool, len(parent))
+	for v := range parent {
+		removedVertices[v] = true
+	}
+	for _, he := range d.HalfEdges {
+		if he.Target != nil {
+			he.Target = find(he.Target)
+		}
+	}
+
+	removedEdges := make(map[*HalfEdge]bool)
+	for _, he := range d.HalfEdges {
+		if he.Target == nil || he.Origin() != he.Target {
+			continue
+		}
+		removedEdges[he] = true
+		if he.Prev != nil {
+			he.Prev.Next = he.Next
+		}
+		if he.Next != nil {
+			he.Next.Prev = he.Prev
+		}
+		// Faces pointing to the half-edge move on to the next one, which is never a removed one
+		var next *HalfEdge
+		if he.Next != he {
+			next = he.Next
+		}
+		if f := he.Face; f != nil {
+			if f.HalfEdge == he {
+				f.HalfEdge = next
+			}
+			for i, c := range f.InnerComponents {
+				if c == he {
+					f.InnerComponents[i] = next
+				}
+			}
+		}
+	}
+
+	removedFaces := make(map[*Face]bool)
+	for _, f := range d.Faces {
+		holes := f.InnerComponents[:0]
+		for _, c := range f.InnerComponents {
+			if c != nil {
+				holes = append(holes, c)
+			}
+		}
+		f.InnerComponents = holes
+		if f == d.OuterFace {
+			continue
+		}
+		if f.HalfEdge == nil {
+			removedFaces[f] = true
+			continue
+		}
+		edges := f.boundary()
+		if len(edges) != 2 {
+			continue
+		}
+		t1, t2 := edges[0].Twin, edges[1].Twin
+		if t1 == nil || t2 == nil || t1 == edges[1] {
+			continue
+		}
+		t1.Twin, t2.Twin = t2, t1
+		removedEdges[edges[0]], removedEdges[edges[1]] = true, true
+		removedFaces[f] = true
+	}
+
+	for _, v := range d.Vertices {
+		if removedEdges[v.HalfEdge] {
+			v.HalfEdge = nil
+		}
+	}
+	d.remove(removedVertices, removedEdges, removedFaces)
+	d.RepairVertexPointers()
+	return len(removedVertices)
+}
+
+// MoveVertex moves the vertex to the point (x, y), after checking that the subdivision stays
+// planar. An error is returned and the vertex is left in place if, at the new position, an edge
+// incident to the vertex would cross or touch an edge it does not share an endpoint with or pass
+// through another vertex, if the vertex would coincide with another vertex or lie on an edge, or
+// if a face around the vertex would flip its orientation. All edges of the structure are checked,
+// not just the ones near the vertex.
+func (d *DCEL) MoveVertex(v *Vertex, x, y int) error {
+	p := &Vertex{X: x, Y: y}
+	incident := v.incoming()
+	var neighbours []*Vertex
+	faces := make(map[*Face]float64)
+	for _, he := range incident {
+		if origin := he.Origin(); origin != nil {
+			neighbours = append(neighbours, origin)
+		}
+		left, right := he.Faces()
+		for _, f := range []*Face{left, right} {
+			if f != nil {
+				faces[f] = f.Area()
+			}
+		}
+	}
+
+	for _, w := range d.Vertices {
+		if w == v {
+			continue
+		}
+		if w.X == x && w.Y == y {
+			return errors.New("vertex would coincide with another vertex")
+		}
+		for _, a := range neighbours {
+			if onSegment(w, a, p) {
+				return errors.New("edge of the vertex would pass through another vertex")
+			}
+		}
+	}
+	for _, he := range d.edges() {
+		c, dv := he.Origin(), he.Target
+		if c == nil || dv == nil || c == v || dv == v {
+			continue
+		}
+		if onSegment(p, c, dv) {
+			return errors.New("vertex would lie on an edge")
+		}
+		for _, a := range neighbours {
+			if a != c && a != dv && segmentsCross(a, p, c, dv) {
+				return errors.New("edge of the vertex would cross another edge")
+			}
+		}
+	}
+
+	oldX, oldY := v.X, v.Y
+	v.X, v.Y = x, y
+	for f, area := range faces {
+		if after := f.Area(); area > 0 && after <= 0 || area < 0 && after >= 0 {
+			v.X, v.Y = oldX, oldY
+			return errors.New("face around the vertex would flip its orientation")
+		}
+	}
+	if d.recording() {
+		d.record(func() {
+			v.X, v.Y = oldX, oldY
+		})
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package dcel
+
+import "testing"
+
+func TestInsertVertexSnapped(t *testing.T) {
+	d := BuildGrid(1, 1, 10, 10)
+	v, ok := d.InsertVertexSnapped(5, 1, 2)
+	if !ok || v.X != 5 || v.Y != 0 {
+		t.Fatalf("InsertVertexSnapped(5, 1, 2) = %v, %v, want a vertex at (5, 0)", v, ok)
+	}
+	if len(d.Vertices) != 5 {
+		t.Errorf("structure has %d vertices, want 5", len(d.Vertices))
+	}
+	// Snapping to the same point again reuses the vertex
+	if w, ok := d.InsertVertexSnapped(5, 1, 2); !ok || w != v || len(d.Vertices) != 5 {
+		t.Errorf("InsertVertexSnapped(5, 1, 2) = %v, %v with %d vertices, want the existing vertex", w, ok, len(d.Vertices))
+	}
+	if _, ok := d.InsertVertexSnapped(5, 5, 2); ok {
+		t.Error("InsertVertexSnapped(5, 5, 2) snapped to an edge farther than the threshold")
+	}
+}
+
+func TestInsertVertexSnappedOffSegment(t *testing.T) {
+	d, err := BuildArrangement([][4]int{{0, 0, 10, 3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The projection (5.05, 1.51) rounds to (5, 2), which is not on the edge
+	if v, ok := d.InsertVertexSnapped(5, 2, 2); ok {
+		t.Errorf("InsertVertexSnapped(5, 2, 2) = %v, want no snap", v)
+	}
+	if len(d.Vertices) != 2 || len(d.HalfEdges) != 2 {
+		t.Errorf("structure has %d vertices and %d half-edges, want 2 and 2", len(d.Vertices), len(d.HalfEdges))
+	}
+	// The projection of (11, 4) is the endpoint (10, 3)
+	if v, ok := d.InsertVertexSnapped(11, 4, 2); !ok || v.X != 10 || v.Y != 3 || len(d.Vertices) != 2 {
+		t.Errorf("InsertVertexSnapped(11, 4, 2) = %v, %v, want the endpoint (10, 3)", v, ok)
+	}
+	// The edge has no integer points inside of it, so no point near it can be snapped onto it
+	if v, ok := d.InsertVertexSnapped(7, 2, 2); ok {
+		t.Errorf("InsertVertexSnapped(7, 2, 2) = %v, want no snap", v)
+	}
+}
+
+// holedArrangement builds the subdivision of the given segments, whose separate components become
+// holes of the faces they lie in.
+func holedArrangement(t *testing.T, lines [][4]int) *DCEL {
+	t.Helper()
+	d, err := BuildArrangement(lines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+// square returns the four sides of the axis-aligned square with the given corners.
+func square(x0, y0, x1, y1 int) [][4]int {
+	return [][4]int{{x0, y0, x1, y0}, {x1, y0, x1, y1}, {x1, y1, x0, y1}, {x0, y1, x0, y0}}
+}
+
+// faceAt returns the smallest bounded face containing the point, failing the test if there is none.
+func faceAt(t *testing.T, d *DCEL, x, y int) *Face {
+	t.Helper()
+	var found *Face
+	for _, f := range d.boundedFaces() {
+		if f.Classify(x, y) > 0 && (found == nil || f.Area() < found.Area()) {
+			found = f
+		}
+	}
+	if found == nil {
+		t.Fatalf("no face contains (%d, %d)", x, y)
+	}
+	return found
+}
+
+// sharedEdge returns the half-edge of face f whose twin belongs to face g.
+func sharedEdge(t *testing.T, f, g *Face) *HalfEdge {
+	t.Helper()
+	for _, he := range f.HalfEdges() {
+		if he.Twin.Face == g {
+			return he
+		}
+	}
+	t.Fatalf("faces %v and %v share no edge", f, g)
+	return nil
+}
+
+func TestRemoveEdgeMovesHoles(t *testing.T) {
+	// Two cells side by side, the right one with a square hole filled by a face of its own
+	lines := append(square(0, 0, 20, 10), [4]int{10, 0, 10, 10})
+	d := holedArrangement(t, append(lines, square(13, 3, 17, 7)...))
+	left, right := faceAt(t, d, 5, 5), faceAt(t, d, 11, 1)
+	if len(right.InnerComponents) != 1 {
+		t.Fatalf("right face has %d holes, want 1", len(right.InnerComponents))
+	}
+	// The left face survives and takes over the hole of the right one
+	if err := d.RemoveEdge(sharedEdge(t, left, right)); err != nil {
+		t.Fatal(err)
+	}
+	if len(left.InnerComponents) != 1 {
+		t.Errorf("merged face has %d holes, want 1", len(left.InnerComponents))
+	}
+	if err := d.CheckMembership(); err != nil {
+		t.Error(err)
+	}
+	if err := d.ValidateHoles(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRemoveEdgeOfHole(t *testing.T) {
+	d := holedArrangement(t, append(square(0, 0, 10, 10), square(3, 3, 7, 7)...))
+	around, inside := faceAt(t, d, 1, 1), faceAt(t, d, 5, 5)
+	he := sharedEdge(t, inside, around)
+	// The hole is referenced by the half-edge being removed
+	around.InnerComponents[0] = he.Twin
+	// The face in the hole survives and takes over the boundary of the face around it, while the
+	// three remaining sides of the hole become a hole of their own
+	if err := d.RemoveEdge(he); err != nil {
+		t.Fatal(err)
+	}
+	if got := inside.Area(); got != 100 {
+		t.Errorf("merged face has area %v, want 100", got)
+	}
+	if len(inside.InnerComponents) != 1 {
+		t.Fatalf("merged face has %d holes, want 1", len(inside.InnerComponents))
+	}
+	hole := closedCycle(inside.InnerComponents[0])
+	if len(hole) != 6 {
+		t.Errorf("hole has %d half-edges, want 6", len(hole))
+	}
+	for _, e := range hole {
+		if e.Face != inside {
+			t.Errorf("half-edge %v of the hole belongs to face %v, want %v", e, e.Face, inside)
+		}
+	}
+	if err := d.CheckMembership(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRemoveEdgeDetachesHole(t *testing.T) {
+	// A bridge from the corner of the square to the corner of the square hole makes their
+	// boundaries a single cycle
+	lines := append(square(0, 0, 20, 20), square(5, 5, 15, 15)...)
+	d := holedArrangement(t, append(lines, [4]int{0, 0, 5, 5}))
+	around := faceAt(t, d, 1, 10)
+	if len(around.InnerComponents) != 0 {
+		t.Fatalf("face has %d holes before removing the bridge, want 0", len(around.InnerComponents))
+	}
+	var bridge *HalfEdge
+	for _, he := range around.HalfEdges() {
+		if he.Twin.Face == around {
+			bridge = he
+		}
+	}
+	if err := d.RemoveEdge(bridge); err != nil {
+		t.Fatal(err)
+	}
+	if got := around.Area(); got != 400 {
+		t.Errorf("face has area %v, want 400", got)
+	}
+	if len(around.InnerComponents) != 1 {
+		t.Fatalf("face has %d holes, want 1", len(around.InnerComponents))
+	}
+	if got := ringArea(closedCycle(around.InnerComponents[0])); got != -100 {
+		t.Errorf("hole has area %v, want -100", got)
+	}
+	if err := d.ValidateHoles(); err != nil {
+		t.Error(err)
+	}
+}
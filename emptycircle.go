@@ -0,0 +1,96 @@
+package dcel
+
+import "math"
+
+// LargestEmptyCircle returns the center and the radius of the largest circle whose center lies
+// inside the convex hull of the vertices of the structure and whose interior contains none of them.
+// The structure itself is only used as a set of points: the circle is found by building their
+// Delaunay triangulation with BuildConstrainedDelaunay, as the center is either a vertex of the
+// Voronoi diagram, i.e. the circumcenter of a Delaunay triangle, or a point where an edge of the
+// Voronoi diagram crosses the hull. All three values are zero if there are less than three
+// distinct vertices or all of them are collinear.
+func (d *DCEL) LargestEmptyCircle() (cx, cy, r float64) {
+	points := make([][2]int, len(d.Vertices))
+	for i, v := range d.Vertices {
+		points[i] = [2]int{v.X, v.Y}
+	}
+	t, err := BuildConstrainedDelaunay(points, nil)
+	if err != nil {
+		return 0, 0, 0
+	}
+
+	hull := t.OuterFace.boundary()
+	insideHull := func(x, y float64) bool {
+		for _, he := range hull {
+			a, b := he.Origin(), he.Target
+			// The outer face runs clockwise, so the hull lies on the right of its half-edges. Points
+			// computed on the hull itself are accepted despite rounding errors.
+			cross := float64(b.X-a.X)*(y-float64(a.Y)) - float64(b.Y-a.Y)*(x-float64(a.X))
+			if cross/he.Length() > 1e-9 {
+				return false
+			}
+		}
+		return true
+	}
+	consider := func(x, y float64, v *Vertex) {
+		if radius := math.Hypot(x-float64(v.X), y-float64(v.Y)); radius > r && insideHull(x, y) {
+			cx, cy, r = x, y, radius
+		}
+	}
+
+	minX, minY, maxX, maxY := t.OuterFace.BoundingBox()
+	centers := make(map[*Face][2]float64)
+	for _, f := range t.boundedFaces() {
+		x, y, _, err := f.Circumcircle()
+		if err != nil {
+			continue
+		}
+		centers[f] = [2]float64{x, y}
+		consider(x, y, f.HalfEdge.Target)
+	}
+
+	// Each edge of the triangulation is crossed by an edge of the Voronoi diagram, which runs
+	// between the circumcenters of its two triangles, or from the circumcenter outwards at the hull.
+	for _, he := range t.edges() {
+		if he.Face == t.OuterFace {
+			he = he.Twin
+		}
+		a, b := he.Origin(), he.Target
+		start, ok := centers[he.Face]
+		if !ok {
+			continue
+		}
+		end, bounded := centers[he.Twin.Face]
+		if !bounded {
+			// Go far enough to the right of the hull edge to leave the hull
+			length := math.Hypot(float64(maxX-minX), float64(maxY-minY)) +
+				math.Hypot(start[0]-float64(minX), start[1]-float64(minY))
+			nx, ny := float64(b.Y-a.Y)/he.Length(), -float64(b.X-a.X)/he.Length()
+			end = [2]float64{start[0] + nx*length, start[1] + ny*length}
+		}
+		for _, e := range hull {
+			if x, y, ok := segmentIntersection(start, end, e.Origin(), e.Target); ok {
+				consider(x, y, a)
+			}
+		}
+	}
+	return cx, cy, r
+}
+
+// segmentIntersection returns the point where the segment from p to q intersects the segment ab,
+// if they intersect at a single point.
+func segmentIntersection(p, q [2]float64, a, b *Vertex) (float64, float64, bool) {
+	rx, ry := q[0]-p[0], q[1]-p[1]
+	sx, sy := float64(b.X-a.X), float64(b.Y-a.Y)
+	denom := rx*sy - ry*sx
+	if denom == 0 {
+		return 0, 0, false
+	}
+	wx, wy := float64(a.X)-p[0], float64(a.Y)-p[1]
+	t := (wx*sy - wy*sx) / denom
+	u := (wx*ry - wy*rx) / denom
+	if t < 0 || t > 1 || u < 0 || u > 1 {
+		return 0, 0, false
+	}
+	return p[0] + t*rx, p[1] + t*ry, true
+}
@@ -0,0 +1,66 @@
+package dcel
+
+import "sort"
+
+// EdgeList returns the coordinates of the two endpoints of every edge in the structure, as
+// [x1, y1, x2, y2]. Each pair of twin half-edges is reported once, with its endpoints ordered so
+// that (x1, y1) is lexicographically smaller than (x2, y2), and the list is sorted, so the result
+// does not depend on the order in which the structure was built. Edges with a missing endpoint are
+// left out.
+func (d *DCEL) EdgeList() [][4]int {
+	var list [][4]int
+	for _, he := range d.edges() {
+		origin := he.Origin()
+		if origin == nil || he.Target == nil {
+			continue
+		}
+		a, b := [2]int{origin.X, origin.Y}, [2]int{he.Target.X, he.Target.Y}
+		if b[0] < a[0] || b[0] == a[0] && b[1] < a[1] {
+			a, b = b, a
+		}
+		list = append(list, [4]int{a[0], a[1], b[0], b[1]})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		for k := range list[i] {
+			if list[i][k] != list[j][k] {
+				return list[i][k] < list[j][k]
+			}
+		}
+		return false
+	})
+	return list
+}
+
+// Triangles returns the vertices of every bounded face that is a triangle, in the order they
+// appear at the boundary of the face. Faces that are not closed triangles are skipped.
+func (d *DCEL) Triangles() [][3]*Vertex {
+	var triangles [][3]*Vertex
+	for _, face := range d.boundedFaces() {
+		edges := face.boundary()
+		if len(edges) != 3 {
+			continue
+		}
+		triangles = append(triangles, [3]*Vertex{edges[0].Target, edges[1].Target, edges[2].Target})
+	}
+	return triangles
+}
+
+// BoundaryRing returns the coordinates of the vertices on the outer boundary of the subdivision,
+// as a closed ring that repeats the first point at the end. The ring follows the boundary of the
+// outer face backwards, so it has the same orientation as the bounded faces of the structure,
+// i.e. counter-clockwise for the structures built by this package. Nil is returned if OuterFace
+// is not set or its boundary is not closed.
+func (d *DCEL) BoundaryRing() [][2]int {
+	if d.OuterFace == nil {
+		return nil
+	}
+	edges := d.OuterFace.boundary()
+	if len(edges) == 0 {
+		return nil
+	}
+	ring := make([][2]int, 0, len(edges)+1)
+	for i := len(edges) - 1; i >= 0; i-- {
+		ring = append(ring, [2]int{edges[i].Target.X, edges[i].Target.Y})
+	}
+	return append(ring, ring[0])
+}
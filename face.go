@@ -0,0 +1,407 @@
+package dcel
+
+import (
+	"errors"
+	"math"
+)
+
+// HalfEdges returns the half-edges at the boundary of the face, in the order they are linked by
+// their Next pointers, starting with f.HalfEdge. The walk stops at a missing Next pointer or when a
+// half-edge is reached for a second time, so a broken cycle can not cause an infinite loop.
+func (f *Face) HalfEdges() []*HalfEdge {
+	return cycle(f.HalfEdge)
+}
+
+// HalfEdgesReverse returns the half-edges at the boundary of the face in reverse order, following
+// their Prev pointers starting with f.HalfEdge. Like HalfEdges, the walk stops at a missing Prev
+// pointer or when a half-edge is reached for a second time.
+func (f *Face) HalfEdgesReverse() []*HalfEdge {
+	return reverseCycle(f.HalfEdge)
+}
+
+// EdgeIndex returns the 0-based position of he in the boundary of the face as returned by
+// HalfEdges, which is also the position of its target in Vertices, or -1 if he is not on the
+// boundary of the face.
+func (f *Face) EdgeIndex(he *HalfEdge) int {
+	for i, e := range f.HalfEdges() {
+		if e == he {
+			return i
+		}
+	}
+	return -1
+}
+
+// Degree returns the number of half-edges at the boundary of the face.
+func (f *Face) Degree() int {
+	return len(f.HalfEdges())
+}
+
+// boundary returns the half-edges at the boundary of the face if they form a closed cycle in which
+// every half-edge has a target vertex, or nil otherwise.
+func (f *Face) boundary() []*HalfEdge {
+	return closedCycle(f.HalfEdge)
+}
+
+// Vertices returns the target vertices of the boundary half-edges of the face, in the same order
+// as HalfEdges. An entry is nil if the corresponding half-edge has no target vertex yet.
+func (f *Face) Vertices() []*Vertex {
+	edges := f.HalfEdges()
+	vertices := make([]*Vertex, len(edges))
+	for i, he := range edges {
+		vertices[i] = he.Target
+	}
+	return vertices
+}
+
+// Area returns the signed area of the face, calculated with the shoelace formula over its boundary
+// vertices. The area is positive if the boundary is oriented counter-clockwise and negative if it
+// is oriented clockwise. Faces with an open boundary have zero area.
+func (f *Face) Area() float64 {
+	vertices := f.Vertices()
+	var sum int64
+	for i, v := range vertices {
+		next := vertices[(i+1)%len(vertices)]
+		if v == nil || next == nil {
+			return 0
+		}
+		sum += int64(v.X)*int64(next.Y) - int64(next.X)*int64(v.Y)
+	}
+	return float64(sum) / 2
+}
+
+// Centroid returns the center of mass of the area enclosed by the face boundary. For a face with
+// zero area, such as a degenerate or open one, the average of its boundary vertices is returned.
+func (f *Face) Centroid() (float64, float64) {
+	vertices := f.Vertices()
+	var cx, cy, sum float64
+	for i, v := range vertices {
+		next := vertices[(i+1)%len(vertices)]
+		if v == nil || next == nil {
+			sum = 0
+			break
+		}
+		cross := float64(v.X)*float64(next.Y) - float64(next.X)*float64(v.Y)
+		cx += float64(v.X+next.X) * cross
+		cy += float64(v.Y+next.Y) * cross
+		sum += cross
+	}
+	if sum != 0 {
+		return cx / (3 * sum), cy / (3 * sum)
+	}
+
+	cx, cy = 0, 0
+	count := 0
+	for _, v := range vertices {
+		if v != nil {
+			cx += float64(v.X)
+			cy += float64(v.Y)
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, 0
+	}
+	return cx / float64(count), cy / float64(count)
+}
+
+// BoundingBox returns the smallest axis-aligned rectangle containing all boundary vertices of the
+// face. All four values are zero if the face has no boundary vertices.
+func (f *Face) BoundingBox() (minX, minY, maxX, maxY int) {
+	first := true
+	for _, v := range f.Vertices() {
+		if v == nil {
+			continue
+		}
+		if first {
+			minX, minY, maxX, maxY = v.X, v.Y, v.X, v.Y
+			first = false
+		}
+		minX, minY = min(minX, v.X), min(minY, v.Y)
+		maxX, maxY = max(maxX, v.X), max(maxY, v.Y)
+	}
+	return minX, minY, maxX, maxY
+}
+
+// AspectRatio returns a measure of how elongated the face is, which is 1 or more for non-degenerate
+// faces. For a triangle it is the ratio of its circumradius to its inradius, which is 2 for an
+// equilateral triangle and grows without bounds for slivers. For any other face it is the ratio of
+// the longer to the shorter side of its axis-aligned bounding box. Degenerate faces have an
+// infinite aspect ratio, while faces without a boundary have zero.
+func (f *Face) AspectRatio() float64 {
+	if edges := f.boundary(); len(edges) == 3 {
+		a, b, c := edges[0].Length(), edges[1].Length(), edges[2].Length()
+		area := math.Abs(f.Area())
+		if area == 0 {
+			return math.Inf(1)
+		}
+		s := (a + b + c) / 2
+		return a * b * c * s / (4 * area * area)
+	}
+
+	if f.HalfEdge == nil {
+		return 0
+	}
+	minX, minY, maxX, maxY := f.BoundingBox()
+	w, h := float64(maxX-minX), float64(maxY-minY)
+	if w == 0 || h == 0 {
+		return math.Inf(1)
+	}
+	return math.Max(w, h) / math.Min(w, h)
+}
+
+// Circumcircle returns the center and the radius of the circle passing through the three vertices
+// of a triangular face. An error is returned if the face is not a triangle with a closed boundary
+// or if its vertices are collinear.
+func (f *Face) Circumcircle() (cx, cy, r float64, err error) {
+	a, b, c, err := f.triangle()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	ax, ay := float64(a.X), float64(a.Y)
+	bx, by := float64(b.X)-ax, float64(b.Y)-ay
+	qx, qy := float64(c.X)-ax, float64(c.Y)-ay
+	denom := 2 * (bx*qy - by*qx)
+	ux := (qy*(bx*bx+by*by) - by*(qx*qx+qy*qy)) / denom
+	uy := (bx*(qx*qx+qy*qy) - qx*(bx*bx+by*by)) / denom
+	return ax + ux, ay + uy, math.Hypot(ux, uy), nil
+}
+
+// Incircle returns the center and the radius of the largest circle inscribed in a triangular face,
+// which touches all three of its edges. An error is returned if the face is not a triangle with a
+// closed boundary or if its vertices are collinear.
+func (f *Face) Incircle() (cx, cy, r float64, err error) {
+	a, b, c, err := f.triangle()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	// The center is the average of the vertices weighted by the lengths of the opposite sides
+	la, lb, lc := distance(b, c), distance(c, a), distance(a, b)
+	perimeter := la + lb + lc
+	cx = (la*float64(a.X) + lb*float64(b.X) + lc*float64(c.X)) / perimeter
+	cy = (la*float64(a.Y) + lb*float64(b.Y) + lc*float64(c.Y)) / perimeter
+	area := math.Abs(float64(orient(a, b, c))) / 2
+	return cx, cy, 2 * area / perimeter, nil
+}
+
+// triangle returns the three vertices of a triangular face, or an error if the face is not a
+// triangle with a closed boundary or if its vertices are collinear.
+func (f *Face) triangle() (a, b, c *Vertex, err error) {
+	edges := f.boundary()
+	if len(edges) != 3 {
+		return nil, nil, nil, errors.New("face is not a triangle")
+	}
+	a, b, c = edges[0].Target, edges[1].Target, edges[2].Target
+	if orient(a, b, c) == 0 {
+		return nil, nil, nil, errors.New("triangle is degenerate")
+	}
+	return a, b, c, nil
+}
+
+// ToStandaloneDCEL copies the face into a new DCEL, made of copies of its boundary vertices, the
+// face itself and an outer face surrounding it. The ID and Data of the face, and the Data of its
+// vertices and boundary half-edges are copied too. Nil is returned if the boundary of the face is
+// not closed.
+func (f *Face) ToStandaloneDCEL() *DCEL {
+	edges := f.boundary()
+	if len(edges) == 0 {
+		return nil
+	}
+	d := NewDCELWithCapacity(len(edges), 2, 2*len(edges))
+	// Start with the origin of f.HalfEdge, so the copied half-edges follow the original order
+	n := len(edges)
+	vertices := make([]*Vertex, n)
+	copies := make(map[*Vertex]*Vertex)
+	for i, he := range edges {
+		v, ok := copies[he.Target]
+		if !ok {
+			v = d.NewVertex(he.Target.X, he.Target.Y)
+			v.Data = he.Target.Data
+			copies[he.Target] = v
+		}
+		vertices[(i+1)%n] = v
+	}
+
+	d.OuterFace = d.NewFace()
+	face := d.addPolygon(vertices)
+	face.ID, face.Data = f.ID, f.Data
+	for i, he := range face.HalfEdges() {
+		he.Data = edges[i].Data
+	}
+	return d
+}
+
+// Classify tells where the point (x, y) lies relative to the face: it returns 1 if the point is
+// inside the face, 0 if it lies on its boundary and -1 if it is outside of it. Points inside one of
+// the holes of the face are outside of it, and points on the boundary of a hole are on its
+// boundary. The test uses the winding number of the boundaries around the point, so it works for
+// boundaries of either orientation, and treats the face as the region enclosed by its outer
+// boundary, which does not hold for the unbounded outer face. As coordinates are integers, it is
+// evaluated exactly, without any tolerance. A face without a closed boundary contains no points.
+func (f *Face) Classify(x, y int) int {
+	edges := f.boundary()
+	if len(edges) == 0 {
+		return -1
+	}
+	return f.classifyScaled(edges, &Vertex{X: x, Y: y}, 1)
+}
+
+// classifyScaled classifies p like Classify, relative to the face with all of its coordinates
+// multiplied by scale. The closed outer boundary of the face must be given in edges.
+func (f *Face) classifyScaled(edges []*HalfEdge, p *Vertex, scale int) int {
+	class := classifyRingScaled(edges, p, scale)
+	if class <= 0 {
+		return class
+	}
+	for _, hole := range f.InnerComponents {
+		if edges := closedCycle(hole); len(edges) > 0 {
+			if c := classifyRingScaled(edges, p, scale); c >= 0 {
+				return -c
+			}
+		}
+	}
+	return 1
+}
+
+// Contains returns true if the point (x, y) lies inside the face or on its boundary.
+func (f *Face) Contains(x, y int) bool {
+	return f.Classify(x, y) >= 0
+}
+
+// Normalize sets f.HalfEdge to the boundary half-edge starting at the lexicographically smallest
+// boundary vertex, comparing x and then y, so that every traversal of the boundary begins at the
+// same vertex regardless of how the face was built. If that vertex appears more than once on the
+// boundary, the first half-edge leaving it after the current start is chosen. Only the starting
+// pointer is changed. Faces without a closed boundary are left untouched.
+func (f *Face) Normalize() {
+	edges := f.boundary()
+	if len(edges) == 0 {
+		return
+	}
+	best := 0
+	for i := range edges {
+		a := edges[(i+len(edges)-1)%len(edges)].Target
+		b := edges[(best+len(edges)-1)%len(edges)].Target
+		if a.X < b.X || a.X == b.X && a.Y < b.Y {
+			best = i
+		}
+	}
+	f.HalfEdge = edges[best]
+}
+
+// HoleCount returns the number of holes in the face, i.e. the number of its inner components.
+func (f *Face) HoleCount() int {
+	return len(f.InnerComponents)
+}
+
+// IsConvex returns true if the face has a closed boundary enclosing a non-zero area, which turns in
+// the same direction at every vertex and winds around its interior only once. Vertices where the
+// boundary goes straight on are allowed.
+func (f *Face) IsConvex() bool {
+	edges := f.boundary()
+	area := ringArea(edges)
+	if area == 0 {
+		return false
+	}
+	var sum float64
+	for i, he := range edges {
+		next := edges[(i+1)%len(edges)]
+		if float64(orient(he.Origin(), he.Target, next.Target))*area < 0 {
+			return false
+		}
+		sum += he.InteriorAngle()
+	}
+	// The interior angles of a simple polygon add up to (n-2)*pi, while those of a boundary that
+	// winds around more than once are smaller by a multiple of 2*pi.
+	return math.Abs(sum-float64(len(edges)-2)*math.Pi) < math.Pi
+}
+
+// TotalBoundaryLength returns the length of the outer boundary of the face plus the lengths of the
+// boundaries of all of its holes. Half-edges with a missing endpoint do not contribute to it.
+func (f *Face) TotalBoundaryLength() float64 {
+	var total float64
+	for _, he := range f.HalfEdges() {
+		total += he.Length()
+	}
+	for _, hole := range f.InnerComponents {
+		for _, he := range cycle(hole) {
+			total += he.Length()
+		}
+	}
+	return total
+}
+
+// IsSimple returns true if the boundary of the face is a closed cycle of at least three edges that
+// does not intersect itself: edges that are not consecutive have no point in common, and
+// consecutive edges only share their common vertex. The check compares every pair of edges, so it
+// takes quadratic time in the number of edges.
+func (f *Face) IsSimple() bool {
+	edges := f.boundary()
+	n := len(edges)
+	if n < 3 {
+		return false
+	}
+	for i := 0; i < n; i++ {
+		a, b := edges[(i+n-1)%n].Target, edges[i].Target
+		for j := i + 1; j < n; j++ {
+			c, d := edges[j-1].Target, edges[j].Target
+			switch {
+			case j == i+1:
+				// d continues from b, so it must not fold back onto ab, nor a onto bd
+				if onSegment(d, a, b) || onSegment(a, b, d) || d.X == a.X && d.Y == a.Y {
+					return false
+				}
+			case i == 0 && j == n-1:
+				// b continues from a (= d), so the same applies the other way round
+				if onSegment(c, a, b) || onSegment(b, c, a) {
+					return false
+				}
+			case segmentsIntersect(a, b, c, d):
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// StraightChains splits the boundary of the face into maximal runs of nearly collinear vertices.
+// A boundary vertex is a corner if it lies farther than epsilon from the line through its two
+// neighbours on the boundary, and every returned chain runs from a corner, through the vertices
+// that are not corners, to the next corner, so consecutive chains share their end vertices. The
+// chains follow the order of Vertices, starting at the first corner. As each vertex is only
+// compared with its neighbours, a gentle curve made of many short edges can still form a single
+// chain. If no vertex is a corner, the whole boundary is returned as one chain, and nil is
+// returned for a face without a closed boundary.
+func (f *Face) StraightChains(epsilon float64) [][]*Vertex {
+	edges := f.boundary()
+	n := len(edges)
+	if n == 0 {
+		return nil
+	}
+	vertices := make([]*Vertex, n)
+	for i, he := range edges {
+		vertices[i] = he.Target
+	}
+	first := -1
+	corner := make([]bool, n)
+	for i, v := range vertices {
+		corner[i] = distanceToLine(v, vertices[(i+n-1)%n], vertices[(i+1)%n]) > epsilon
+		if corner[i] && first < 0 {
+			first = i
+		}
+	}
+	if first < 0 {
+		return [][]*Vertex{vertices}
+	}
+
+	var chains [][]*Vertex
+	chain := []*Vertex{vertices[first]}
+	for k := 1; k <= n; k++ {
+		i := (first + k) % n
+		chain = append(chain, vertices[i])
+		if corner[i] {
+			chains = append(chains, chain)
+			chain = []*Vertex{vertices[i]}
+		}
+	}
+	return chains
+}
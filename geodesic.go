@@ -0,0 +1,109 @@
+package dcel
+
+import (
+	"container/heap"
+	"errors"
+	"sort"
+)
+
+// ShortestPathInside returns the shortest path between the points from and to that stays inside
+// the face, going around its holes, as the list of its points starting with from and ending with
+// to. The path may run along the boundary of the face and of its holes. It is found with
+// Dijkstra's algorithm on the visibility graph of the two points and the boundary vertices, in
+// which two points are connected if the segment between them does not leave the face. An error
+// is returned if either point lies outside of the face or inside one of its holes, or if the points
+// can not be connected inside the face.
+func (f *Face) ShortestPathInside(from, to [2]int) ([][2]int, error) {
+	if f.Classify(from[0], from[1]) < 0 || f.Classify(to[0], to[1]) < 0 {
+		return nil, errors.New("point lies outside of the face or inside one of its holes")
+	}
+	outer := f.boundary()
+	rings := [][]*HalfEdge{outer}
+	for _, hole := range f.InnerComponents {
+		if edges := closedCycle(hole); len(edges) > 0 {
+			rings = append(rings, edges)
+		}
+	}
+
+	start, end := &Vertex{X: from[0], Y: from[1]}, &Vertex{X: to[0], Y: to[1]}
+	nodes := []*Vertex{start, end}
+	seen := map[[2]int]bool{from: true, to: true}
+	var segments [][2]*Vertex
+	for _, ring := range rings {
+		for i, he := range ring {
+			segments = append(segments, [2]*Vertex{ring[(i+len(ring)-1)%len(ring)].Target, he.Target})
+			if p := [2]int{he.Target.X, he.Target.Y}; !seen[p] {
+				seen[p] = true
+				nodes = append(nodes, he.Target)
+			}
+		}
+	}
+
+	// visible tells whether the segment pq stays inside the face. Between two consecutive boundary
+	// vertices touched by the segment, it either crosses an edge, or lies inside, outside or on the
+	// boundary as a whole, which is told by its midpoint.
+	visible := func(p, q *Vertex) bool {
+		if p.X == q.X && p.Y == q.Y {
+			return true
+		}
+		stops := []*Vertex{p, q}
+		for _, s := range segments {
+			if segmentsCross(p, q, s[0], s[1]) {
+				return false
+			}
+			if onSegment(s[1], p, q) {
+				stops = append(stops, s[1])
+			}
+		}
+		dist := func(v *Vertex) int64 {
+			return int64(v.X-p.X)*int64(q.X-p.X) + int64(v.Y-p.Y)*int64(q.Y-p.Y)
+		}
+		sort.Slice(stops, func(i, j int) bool { return dist(stops[i]) < dist(stops[j]) })
+		for i := 1; i < len(stops); i++ {
+			mid := &Vertex{X: stops[i-1].X + stops[i].X, Y: stops[i-1].Y + stops[i].Y}
+			if f.classifyScaled(outer, mid, 2) < 0 {
+				return false
+			}
+		}
+		return true
+	}
+
+	dist := map[*Vertex]float64{start: 0}
+	prev := make(map[*Vertex]*Vertex)
+	done := make(map[*Vertex]bool)
+	queue := &vertexQueue{{vertex: start}}
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(vertexDist)
+		v := item.vertex
+		if done[v] {
+			continue
+		}
+		done[v] = true
+		if v == end {
+			break
+		}
+		for _, w := range nodes {
+			if done[w] || !visible(v, w) {
+				continue
+			}
+			alt := item.dist + distance(v, w)
+			if old, ok := dist[w]; !ok || alt < old {
+				dist[w], prev[w] = alt, v
+				heap.Push(queue, vertexDist{vertex: w, dist: alt})
+			}
+		}
+	}
+	if !done[end] {
+		return nil, errors.New("points can not be connected inside the face")
+	}
+
+	path := [][2]int{to}
+	for v := end; v != start; {
+		v = prev[v]
+		path = append(path, [2]int{v.X, v.Y})
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, nil
+}
@@ -0,0 +1,202 @@
+package dcel
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
+// adjacency maps each vertex to the edges incident to it, each edge given by one half-edge of its
+// pair as returned by edges. Edges with a missing endpoint are left out.
+func (d *DCEL) adjacency() map[*Vertex][]*HalfEdge {
+	adj := make(map[*Vertex][]*HalfEdge)
+	for _, he := range d.edges() {
+		origin := he.Origin()
+		if origin == nil || he.Target == nil {
+			continue
+		}
+		adj[origin] = append(adj[origin], he)
+		if he.Target != origin {
+			adj[he.Target] = append(adj[he.Target], he)
+		}
+	}
+	return adj
+}
+
+// otherEnd returns the endpoint of the edge of he that is opposite to v.
+func otherEnd(he *HalfEdge, v *Vertex) *Vertex {
+	if he.Target == v {
+		return he.Origin()
+	}
+	return he.Target
+}
+
+// Bridges returns the edges whose removal would disconnect the graph formed by the vertices and
+// edges of the structure, each given by one of its two half-edges. Bridges are found with a
+// depth-first search, keeping track of the earliest visited vertex reachable from each subtree.
+func (d *DCEL) Bridges() []*HalfEdge {
+	adj := d.adjacency()
+	discovered := make(map[*Vertex]int)
+	low := make(map[*Vertex]int)
+	time := 0
+
+	type frame struct {
+		v    *Vertex
+		via  *HalfEdge
+		next int
+	}
+	var bridges []*HalfEdge
+	for _, root := range d.Vertices {
+		if _, ok := discovered[root]; ok {
+			continue
+		}
+		discovered[root], low[root] = time, time
+		time++
+		stack := []frame{{v: root}}
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if top.next < len(adj[top.v]) {
+				e := adj[top.v][top.next]
+				top.next++
+				if e == top.via {
+					continue
+				}
+				w := otherEnd(e, top.v)
+				if t, ok := discovered[w]; ok {
+					low[top.v] = min(low[top.v], t)
+					continue
+				}
+				discovered[w], low[w] = time, time
+				time++
+				stack = append(stack, frame{v: w, via: e})
+				continue
+			}
+
+			done := *top
+			stack = stack[:len(stack)-1]
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1].v
+				low[parent] = min(low[parent], low[done.v])
+				if low[done.v] > discovered[parent] {
+					bridges = append(bridges, done.via)
+				}
+			}
+		}
+	}
+	return bridges
+}
+
+// ShortestPath returns the shortest path along the edges of the structure between the two
+// vertices, found with Dijkstra's algorithm using the length of each edge as its weight. The path
+// is returned as the list of visited vertices, starting with from and ending with to, together
+// with its total length. If there is no path between the vertices, nil and positive infinity are
+// returned.
+func (d *DCEL) ShortestPath(from, to *Vertex) ([]*Vertex, float64) {
+	adj := d.adjacency()
+	dist := map[*Vertex]float64{from: 0}
+	prev := make(map[*Vertex]*Vertex)
+	done := make(map[*Vertex]bool)
+	queue := &vertexQueue{{vertex: from}}
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(vertexDist)
+		v := item.vertex
+		if done[v] {
+			continue
+		}
+		done[v] = true
+		if v == to {
+			break
+		}
+		for _, e := range adj[v] {
+			w := otherEnd(e, v)
+			alt := item.dist + e.Length()
+			if old, ok := dist[w]; !ok || alt < old {
+				dist[w], prev[w] = alt, v
+				heap.Push(queue, vertexDist{vertex: w, dist: alt})
+			}
+		}
+	}
+
+	if !done[to] {
+		return nil, math.Inf(1)
+	}
+	path := []*Vertex{to}
+	for v := to; v != from; {
+		v = prev[v]
+		path = append(path, v)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, dist[to]
+}
+
+// MinimumSpanningTree returns the edges of a minimum spanning tree of the graph formed by the
+// vertices and edges of the structure, using the length of each edge as its weight, each edge given
+// by one of its two half-edges. The tree is found with Kruskal's algorithm. If the graph has
+// several connected components, the result is the concatenation of the trees of the components,
+// ordered by the position of their first vertex in the structure, with the edges of each tree in
+// ascending order of length. Edges with a missing endpoint and edges connecting a vertex to itself
+// are ignored.
+func (d *DCEL) MinimumSpanningTree() []*HalfEdge {
+	var edges []*HalfEdge
+	for _, he := range d.edges() {
+		if origin := he.Origin(); origin != nil && he.Target != nil && origin != he.Target {
+			edges = append(edges, he)
+		}
+	}
+	sort.SliceStable(edges, func(i, j int) bool { return edges[i].Length() < edges[j].Length() })
+
+	parent := make(map[*Vertex]*Vertex)
+	var find func(v *Vertex) *Vertex
+	find = func(v *Vertex) *Vertex {
+		p, ok := parent[v]
+		if !ok {
+			return v
+		}
+		root := find(p)
+		parent[v] = root
+		return root
+	}
+	var tree []*HalfEdge
+	for _, he := range edges {
+		a, b := find(he.Origin()), find(he.Target)
+		if a != b {
+			parent[b] = a
+			tree = append(tree, he)
+		}
+	}
+
+	components := make(map[*Vertex][]*HalfEdge)
+	for _, he := range tree {
+		root := find(he.Target)
+		components[root] = append(components[root], he)
+	}
+	result := make([]*HalfEdge, 0, len(tree))
+	for _, v := range d.Vertices {
+		root := find(v)
+		result = append(result, components[root]...)
+		delete(components, root)
+	}
+	return result
+}
+
+// vertexDist is a vertex paired with its tentative distance from the source of a search.
+type vertexDist struct {
+	vertex *Vertex
+	dist   float64
+}
+
+// vertexQueue is a min-heap of vertices ordered by distance, implementing heap.Interface.
+type vertexQueue []vertexDist
+
+func (q vertexQueue) Len() int            { return len(q) }
+func (q vertexQueue) Less(i, j int) bool  { return q[i].dist < q[j].dist }
+func (q vertexQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *vertexQueue) Push(x interface{}) { *q = append(*q, x.(vertexDist)) }
+func (q *vertexQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,80 @@
+package dcel
+
+// BuildGrid builds a regular grid of cols by rows rectangular cells, each cellW wide and cellH
+// high, with its lower-left corner at the origin. Every cell is a counter-clockwise bounded face,
+// stored row by row from the bottom, and the region around the grid is stored as OuterFace. All
+// half-edges are linked with their twins and neighbours. An empty structure is returned if any of
+// the arguments is not positive, or if the faces of the cells can not be linked.
+func BuildGrid(cols, rows, cellW, cellH int) *DCEL {
+	return buildGrid(cols, rows, cellW, cellH, false)
+}
+
+// BuildTriGrid builds a regular grid like BuildGrid, but splits each cell into two triangles along
+// the diagonal from its lower-left to its upper-right corner, the lower-right triangle coming
+// first.
+func BuildTriGrid(cols, rows, cellW, cellH int) *DCEL {
+	return buildGrid(cols, rows, cellW, cellH, true)
+}
+
+// buildGrid builds the grids of BuildGrid and BuildTriGrid.
+func buildGrid(cols, rows, cellW, cellH int, triangles bool) *DCEL {
+	d := NewDCEL()
+	if cols <= 0 || rows <= 0 || cellW <= 0 || cellH <= 0 {
+		return d
+	}
+	vertices := make([][]*Vertex, rows+1)
+	for j := range vertices {
+		vertices[j] = make([]*Vertex, cols+1)
+		for i := range vertices[j] {
+			vertices[j][i] = d.NewVertex(i*cellW, j*cellH)
+		}
+	}
+
+	var polygons [][]*Vertex
+	for j := 0; j < rows; j++ {
+		for i := 0; i < cols; i++ {
+			a, b := vertices[j][i], vertices[j][i+1]
+			c, e := vertices[j+1][i+1], vertices[j+1][i]
+			if triangles {
+				polygons = append(polygons, []*Vertex{a, b, c}, []*Vertex{a, c, e})
+			} else {
+				polygons = append(polygons, []*Vertex{a, b, c, e})
+			}
+		}
+	}
+	if err := d.addPolygons(polygons); err != nil {
+		return NewDCEL()
+	}
+	return d
+}
+
+// addPolygons adds the given polygons, whose vertices must be in the structure already and be
+// listed counter-clockwise, as bounded faces sharing the edges they have in common. The edges that
+// belong to a single polygon get a twin in a new OuterFace. The faces are linked with
+// RebuildFaces, so the polygons must not overlap, and its error is returned.
+func (d *DCEL) addPolygons(polygons [][]*Vertex) error {
+	d.OuterFace = d.NewFace()
+	type key struct{ origin, target *Vertex }
+	halfEdges := make(map[key]*HalfEdge)
+	var open []key
+	for _, polygon := range polygons {
+		face := d.NewFace()
+		for i, v := range polygon {
+			next := polygon[(i+1)%len(polygon)]
+			he := d.addHalfEdge(face, next)
+			halfEdges[key{v, next}] = he
+			if twin, ok := halfEdges[key{next, v}]; ok {
+				he.Twin, twin.Twin = twin, he
+			} else {
+				open = append(open, key{v, next})
+			}
+		}
+	}
+	for _, k := range open {
+		if he := halfEdges[k]; he.Twin == nil {
+			he.Twin = d.addHalfEdge(d.OuterFace, k.origin)
+			he.Twin.Twin = he
+		}
+	}
+	return d.RebuildFaces()
+}
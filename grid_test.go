@@ -0,0 +1,75 @@
+package dcel
+
+import "testing"
+
+// assertLinked fails the test if a half-edge of the structure is not linked consistently with its
+// twin and its neighbours, if a vertex does not point at a half-edge ending at it, or if an object
+// referenced from the structure is not stored in it.
+func assertLinked(t *testing.T, d *DCEL) {
+	t.Helper()
+	for _, he := range d.HalfEdges {
+		switch {
+		case he.Twin == nil || he.Twin.Twin != he:
+			t.Errorf("half-edge %v is not the twin of its twin", he)
+		case he.Next == nil || he.Next.Prev != he || he.Prev == nil || he.Prev.Next != he:
+			t.Errorf("half-edge %v is not linked with its neighbours", he)
+		case he.Next.Origin() != he.Target:
+			t.Errorf("half-edge %v is not followed by a half-edge leaving its target", he)
+		case he.Face == nil || he.Next.Face != he.Face:
+			t.Errorf("half-edge %v does not share the face of the next half-edge", he)
+		}
+	}
+	for _, v := range d.Vertices {
+		if v.HalfEdge == nil || v.HalfEdge.Target != v {
+			t.Errorf("vertex %v does not point at a half-edge ending at it", v)
+		}
+	}
+	if err := d.CheckMembership(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBuildGrid(t *testing.T) {
+	tests := []struct {
+		cols, rows, cellW, cellH int
+		triangles                bool
+	}{
+		{1, 1, 10, 10, false},
+		{3, 2, 10, 5, false},
+		{5, 5, 1, 1, false},
+		{1, 1, 10, 10, true},
+		{4, 3, 2, 7, true},
+	}
+	for _, tt := range tests {
+		d, cells, area := BuildGrid(tt.cols, tt.rows, tt.cellW, tt.cellH), tt.cols*tt.rows, float64(tt.cellW*tt.cellH)
+		if tt.triangles {
+			d, cells, area = BuildTriGrid(tt.cols, tt.rows, tt.cellW, tt.cellH), 2*cells, area/2
+		}
+		if got, want := len(d.Vertices), (tt.cols+1)*(tt.rows+1); got != want {
+			t.Errorf("%v: grid has %d vertices, want %d", tt, got, want)
+		}
+		if got := len(d.Faces); got != cells+1 {
+			t.Errorf("%v: grid has %d faces, want %d", tt, got, cells+1)
+		}
+		if got := d.EulerCharacteristic(); got != 2 {
+			t.Errorf("%v: EulerCharacteristic() = %d, want 2", tt, got)
+		}
+		for _, f := range d.boundedFaces() {
+			if f.Area() != area {
+				t.Errorf("%v: cell has area %v, want %v", tt, f.Area(), area)
+			}
+		}
+		if got := d.OuterFace.Area(); got != -float64(tt.cols*tt.rows*tt.cellW*tt.cellH) {
+			t.Errorf("%v: outer face has area %v, want the negated area of the grid", tt, got)
+		}
+		assertLinked(t, d)
+	}
+}
+
+func TestBuildGridEmpty(t *testing.T) {
+	for _, args := range [][4]int{{0, 1, 1, 1}, {1, -1, 1, 1}, {1, 1, 0, 1}, {1, 1, 1, -2}} {
+		if d := BuildGrid(args[0], args[1], args[2], args[3]); len(d.Vertices) != 0 || len(d.Faces) != 0 {
+			t.Errorf("BuildGrid(%v) has %d vertices and %d faces, want none", args, len(d.Vertices), len(d.Faces))
+		}
+	}
+}
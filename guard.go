@@ -0,0 +1,103 @@
+package dcel
+
+// GuardPositions returns a set of vertices from which the whole interior of a simple polygon is
+// visible, placing a guard at each of them, following Fisk's proof of the art gallery theorem:
+// the vertices of a triangulation of the polygon are colored with three colors so that the
+// corners of every triangle get different colors, and the vertices of the least used color are
+// returned. Every triangle has a corner of that color and is visible from it, so the set is valid
+// and has at most n/3 vertices for a polygon with n vertices, but it is not necessarily the
+// smallest one possible.
+//
+// The structure must either be a single simple polygon, as reported by IsSinglePolygon, which is
+// then triangulated with BuildConstrainedDelaunay, or a triangulation of one, as accepted by
+// TriangulationDualTree. The returned vertices belong to the structure and are listed in the order
+// they are stored. Otherwise, or if the polygon can not be triangulated, nil is returned.
+func (d *DCEL) GuardPositions() []*Vertex {
+	var triangles []*Face
+	original := make(map[*Vertex]*Vertex)
+	if _, err := d.TriangulationDualTree(); err == nil {
+		triangles = d.boundedFaces()
+		for _, v := range d.Vertices {
+			original[v] = v
+		}
+	} else if d.IsSinglePolygon() {
+		boundary := d.boundedFaces()[0].boundary()
+		points := make([][2]int, len(boundary))
+		constraints := make([][2]int, len(boundary))
+		byCoords := make(map[[2]int]*Vertex, len(boundary))
+		for i, he := range boundary {
+			points[i] = [2]int{he.Target.X, he.Target.Y}
+			constraints[i] = [2]int{i, (i + 1) % len(boundary)}
+			byCoords[points[i]] = he.Target
+		}
+		t, err := BuildConstrainedDelaunay(points, constraints)
+		if err != nil {
+			return nil
+		}
+		// The boundary is constrained, so every triangle lies either inside or outside of it
+		for _, f := range t.boundedFaces() {
+			if x, y := f.Centroid(); signedDistance([][]*HalfEdge{boundary}, x, y) > 0 {
+				triangles = append(triangles, f)
+			}
+		}
+		for _, v := range t.Vertices {
+			original[v] = byCoords[[2]int{v.X, v.Y}]
+		}
+	}
+	if len(triangles) == 0 {
+		return nil
+	}
+
+	// Color the corners of the first triangle, then move across the edges of the dual tree, each
+	// step reaching a triangle that shares two colored corners with the previous one
+	inside := make(map[*Face]bool, len(triangles))
+	for _, f := range triangles {
+		inside[f] = true
+	}
+	color := make(map[*Vertex]int)
+	for i, v := range triangles[0].Vertices() {
+		color[v] = i
+	}
+	visited := map[*Face]bool{triangles[0]: true}
+	queue := []*Face{triangles[0]}
+	for len(queue) > 0 {
+		f := queue[0]
+		queue = queue[1:]
+		for _, he := range f.HalfEdges() {
+			next := he.Twin.Face
+			if !inside[next] || visited[next] {
+				continue
+			}
+			visited[next] = true
+			queue = append(queue, next)
+			apex := he.Twin.Next.Target
+			color[apex] = 3 - color[he.Target] - color[he.Twin.Target]
+		}
+	}
+
+	var count [3]int
+	for v, c := range color {
+		if original[v] != nil {
+			count[c]++
+		}
+	}
+	least := 0
+	for c := range count {
+		if count[c] < count[least] {
+			least = c
+		}
+	}
+	guarded := make(map[*Vertex]bool)
+	for v, c := range color {
+		if c == least {
+			guarded[original[v]] = true
+		}
+	}
+	var guards []*Vertex
+	for _, v := range d.Vertices {
+		if guarded[v] {
+			guards = append(guards, v)
+		}
+	}
+	return guards
+}
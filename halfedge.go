@@ -0,0 +1,108 @@
+package dcel
+
+import "math"
+
+// Origin returns the vertex the half-edge starts from. This is the target of its twin, or if the
+// half-edge has no twin, the target of the previous half-edge at the boundary of its face.
+func (he *HalfEdge) Origin() *Vertex {
+	if he.Twin != nil {
+		return he.Twin.Target
+	}
+	if he.Prev != nil {
+		return he.Prev.Target
+	}
+	return nil
+}
+
+// InteriorAngle returns the angle in radians, measured inside the face of the half-edge, between
+// the half-edge and the next one at the boundary, i.e. the corner of the face at he.Target. The
+// orientation of the face boundary is determined by the sign of its area, and a counter-clockwise
+// boundary is assumed if the half-edge has no face. The angle is zero if any of the three vertices
+// forming the corner is missing.
+func (he *HalfEdge) InteriorAngle() float64 {
+	origin := he.Origin()
+	if origin == nil || he.Target == nil || he.Next == nil || he.Next.Target == nil {
+		return 0
+	}
+	v, next := he.Target, he.Next.Target
+
+	in := math.Atan2(float64(origin.Y-v.Y), float64(origin.X-v.X))
+	out := math.Atan2(float64(next.Y-v.Y), float64(next.X-v.X))
+	angle := in - out
+	if he.Face != nil && he.Face.Area() < 0 {
+		angle = -angle
+	}
+	if angle < 0 {
+		angle += 2 * math.Pi
+	}
+	return angle
+}
+
+// TurnAngle returns the signed angle in radians by which the direction of the boundary changes at
+// he.Target, when going from the half-edge to the next one. It is positive for a turn to the left,
+// i.e. counter-clockwise, negative for a turn to the right and lies in the range (-pi, pi]. The
+// turn angles around a simple face add up to 2*pi if its boundary is oriented counter-clockwise and
+// to -2*pi otherwise. The angle is zero if any of the three vertices is missing.
+func (he *HalfEdge) TurnAngle() float64 {
+	origin := he.Origin()
+	if origin == nil || he.Target == nil || he.Next == nil || he.Next.Target == nil {
+		return 0
+	}
+	v, next := he.Target, he.Next.Target
+	in := math.Atan2(float64(v.Y-origin.Y), float64(v.X-origin.X))
+	out := math.Atan2(float64(next.Y-v.Y), float64(next.X-v.X))
+	angle := out - in
+	if angle <= -math.Pi {
+		angle += 2 * math.Pi
+	} else if angle > math.Pi {
+		angle -= 2 * math.Pi
+	}
+	return angle
+}
+
+// Length returns the euclidean distance between the origin and the target of the half-edge, or
+// zero if either of them is missing.
+func (he *HalfEdge) Length() float64 {
+	origin := he.Origin()
+	if origin == nil || he.Target == nil {
+		return 0
+	}
+	return math.Hypot(float64(he.Target.X-origin.X), float64(he.Target.Y-origin.Y))
+}
+
+// Faces returns the faces on both sides of the edge. Looking from the origin of the half-edge
+// towards its target, left is the face of the half-edge itself and right is the face of its twin,
+// assuming bounded faces are oriented counter-clockwise, as they are by the builders in this
+// package. Right is nil if the half-edge has no twin.
+func (he *HalfEdge) Faces() (left, right *Face) {
+	if he.Twin != nil {
+		right = he.Twin.Face
+	}
+	return he.Face, right
+}
+
+// IsBoundary returns true if the half-edge or its twin belongs to the outer face of its structure,
+// i.e. if the edge lies on the outer boundary of the subdivision. The outer face is found through
+// the faces themselves, so false is returned for half-edges whose faces were not created by
+// NewFace or RebuildFaces.
+func (he *HalfEdge) IsBoundary() bool {
+	return isOuter(he.Face) || he.Twin != nil && isOuter(he.Twin.Face)
+}
+
+// isOuter returns true if the face is the OuterFace of the structure it belongs to.
+func isOuter(f *Face) bool {
+	return f != nil && f.dcel != nil && f == f.dcel.OuterFace
+}
+
+// CommonVertex returns an endpoint, origin or target, shared by the two half-edges, or nil if they
+// have none in common. If the half-edges share both of their endpoints, as twins and parallel
+// edges do, the target of a is returned. Missing endpoints never match.
+func CommonVertex(a, b *HalfEdge) *Vertex {
+	bOrigin := b.Origin()
+	for _, v := range []*Vertex{a.Target, a.Origin()} {
+		if v != nil && (v == b.Target || v == bOrigin) {
+			return v
+		}
+	}
+	return nil
+}
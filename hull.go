@@ -0,0 +1,140 @@
+package dcel
+
+import (
+	"errors"
+	"sort"
+)
+
+// BuildConvexHull computes the convex hull of the given points and returns it as a DCEL with a
+// single bounded face, oriented counter-clockwise, and an outer face. Points lying on an edge of
+// the hull, between two of its corners, are not part of the result. It is the same as calling
+// BuildConvexHullOpts with keepCollinear set to false.
+func BuildConvexHull(points [][2]int) (*DCEL, error) {
+	return BuildConvexHullOpts(points, false)
+}
+
+// BuildConvexHullOpts computes the convex hull of the given points with Andrew's monotone chain
+// algorithm. If keepCollinear is true, points that lie on an edge of the hull between two of its
+// corners become vertices of the hull too, otherwise only the corners are kept. Duplicate points
+// are merged. The hull is returned as a DCEL with a single counter-clockwise bounded face and an
+// outer face.
+//
+// If the points are all collinear, the hull has no area and the result has no bounded face.
+// Instead it holds the segment between the two extreme points, or the chain through all of the
+// points if keepCollinear is true, with both sides of each edge in the outer face. A single
+// distinct point results in a lone vertex. An error is returned if there are no points.
+func BuildConvexHullOpts(points [][2]int, keepCollinear bool) (*DCEL, error) {
+	if len(points) == 0 {
+		return nil, errors.New("no points given")
+	}
+	d := NewDCEL()
+	byCoords := make(map[[2]int]bool)
+	var sorted []*Vertex
+	for _, p := range points {
+		if !byCoords[p] {
+			byCoords[p] = true
+			sorted = append(sorted, &Vertex{X: p[0], Y: p[1]})
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].X != sorted[j].X {
+			return sorted[i].X < sorted[j].X
+		}
+		return sorted[i].Y < sorted[j].Y
+	})
+
+	collinear := true
+	for _, v := range sorted[min(2, len(sorted)):] {
+		if orient(sorted[0], sorted[1], v) != 0 {
+			collinear = false
+			break
+		}
+	}
+	if collinear {
+		chain := sorted
+		if !keepCollinear && len(sorted) > 1 {
+			chain = []*Vertex{sorted[0], sorted[len(sorted)-1]}
+		}
+		for _, v := range chain {
+			d.Vertices = append(d.Vertices, v)
+		}
+		d.OuterFace = d.NewFace()
+		d.addChain(chain, d.OuterFace)
+		return d, nil
+	}
+
+	// Pop the last hull vertex while it does not make a strict left turn, or while it makes a
+	// right turn if collinear vertices are kept.
+	turnsRight := func(hull []*Vertex, v *Vertex) bool {
+		o := orient(hull[len(hull)-2], hull[len(hull)-1], v)
+		return o < 0 || o == 0 && !keepCollinear
+	}
+	var lower, upper []*Vertex
+	for _, v := range sorted {
+		for len(lower) >= 2 && turnsRight(lower, v) {
+			lower = lower[:len(lower)-1]
+		}
+		lower = append(lower, v)
+	}
+	for i := len(sorted) - 1; i >= 0; i-- {
+		v := sorted[i]
+		for len(upper) >= 2 && turnsRight(upper, v) {
+			upper = upper[:len(upper)-1]
+		}
+		upper = append(upper, v)
+	}
+	hull := append(lower[:len(lower)-1], upper[:len(upper)-1]...)
+
+	d.Vertices = append(d.Vertices, hull...)
+	d.OuterFace = d.NewFace()
+	d.addPolygon(hull)
+	return d, nil
+}
+
+// addPolygon creates a new face bounded by the given vertices in the given order, which must
+// already be in the structure, and links its twin half-edges into a separate boundary cycle of the
+// outer face, which must be set. The polygon must not share any edges with the rest of the
+// structure. The new face is returned.
+func (d *DCEL) addPolygon(vertices []*Vertex) *Face {
+	face := d.NewFace()
+	n := len(vertices)
+	inner := make([]*HalfEdge, n)
+	outer := make([]*HalfEdge, n)
+	for i, v := range vertices {
+		next := vertices[(i+1)%n]
+		inner[i] = d.addHalfEdge(face, next)
+		outer[i] = d.addHalfEdge(d.OuterFace, v)
+		inner[i].Twin, outer[i].Twin = outer[i], inner[i]
+	}
+	linkCycle(inner...)
+	for i, j := 0, n-1; i < j; i, j = i+1, j-1 {
+		outer[i], outer[j] = outer[j], outer[i]
+	}
+	linkCycle(outer...)
+	face.HalfEdge = inner[0]
+	if d.OuterFace.HalfEdge == nil {
+		d.OuterFace.HalfEdge = outer[0]
+	}
+	return face
+}
+
+// addChain creates edges between consecutive vertices of the given chain, which must already be
+// in the structure, with both sides of every edge in the given face. The half-edges are linked
+// into a single cycle going along the chain and back.
+func (d *DCEL) addChain(chain []*Vertex, face *Face) {
+	if len(chain) < 2 {
+		return
+	}
+	var forward, backward []*HalfEdge
+	for i := 0; i+1 < len(chain); i++ {
+		he := d.addHalfEdge(face, chain[i+1])
+		twin := d.addHalfEdge(face, chain[i])
+		he.Twin, twin.Twin = twin, he
+		forward = append(forward, he)
+		backward = append([]*HalfEdge{twin}, backward...)
+	}
+	linkCycle(append(forward, backward...)...)
+	if face.HalfEdge == nil {
+		face.HalfEdge = forward[0]
+	}
+}
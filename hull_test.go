@@ -0,0 +1,110 @@
+package dcel
+
+import "testing"
+
+// hullCorners returns the coordinates of the boundary of the single bounded face of the hull,
+// starting from its lowest, left-most vertex.
+func hullCorners(t *testing.T, d *DCEL) [][2]int {
+	t.Helper()
+	faces := d.boundedFaces()
+	if len(faces) != 1 {
+		t.Fatalf("hull has %d bounded faces, want 1", len(faces))
+	}
+	if area := faces[0].Area(); area <= 0 {
+		t.Fatalf("hull face has area %v, want a counter-clockwise face", area)
+	}
+	var corners [][2]int
+	first := 0
+	for i, v := range faces[0].Vertices() {
+		corners = append(corners, [2]int{v.X, v.Y})
+		if c := corners[first]; v.Y < c[1] || v.Y == c[1] && v.X < c[0] {
+			first = i
+		}
+	}
+	return append(corners[first:], corners[:first]...)
+}
+
+func TestBuildConvexHullOptsSquare(t *testing.T) {
+	// The corners of a square, a point on each of its sides and one inside of it
+	points := [][2]int{{0, 0}, {4, 4}, {2, 0}, {4, 0}, {0, 2}, {4, 2}, {2, 4}, {0, 4}, {2, 2}, {4, 4}}
+	tests := []struct {
+		keepCollinear bool
+		want          [][2]int
+	}{
+		{false, [][2]int{{0, 0}, {4, 0}, {4, 4}, {0, 4}}},
+		{true, [][2]int{{0, 0}, {2, 0}, {4, 0}, {4, 2}, {4, 4}, {2, 4}, {0, 4}, {0, 2}}},
+	}
+	for _, tt := range tests {
+		d, err := BuildConvexHullOpts(points, tt.keepCollinear)
+		if err != nil {
+			t.Fatalf("keepCollinear %v: %v", tt.keepCollinear, err)
+		}
+		got := hullCorners(t, d)
+		if len(got) != len(tt.want) {
+			t.Fatalf("keepCollinear %v: hull is %v, want %v", tt.keepCollinear, got, tt.want)
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Fatalf("keepCollinear %v: hull is %v, want %v", tt.keepCollinear, got, tt.want)
+			}
+		}
+		if len(d.Vertices) != len(tt.want) {
+			t.Errorf("keepCollinear %v: structure has %d vertices, want %d", tt.keepCollinear, len(d.Vertices), len(tt.want))
+		}
+		if err := d.CheckMembership(); err != nil {
+			t.Errorf("keepCollinear %v: %v", tt.keepCollinear, err)
+		}
+	}
+}
+
+func TestBuildConvexHullOptsCollinear(t *testing.T) {
+	points := [][2]int{{2, 2}, {0, 0}, {6, 6}, {4, 4}, {2, 2}}
+	tests := []struct {
+		keepCollinear bool
+		want          [][2]int
+	}{
+		{false, [][2]int{{0, 0}, {6, 6}}},
+		{true, [][2]int{{0, 0}, {2, 2}, {4, 4}, {6, 6}}},
+	}
+	for _, tt := range tests {
+		d, err := BuildConvexHullOpts(points, tt.keepCollinear)
+		if err != nil {
+			t.Fatalf("keepCollinear %v: %v", tt.keepCollinear, err)
+		}
+		// The documented result: no bounded face, and the chain through the points with both sides
+		// of each edge in the outer face
+		if faces := d.boundedFaces(); len(faces) != 0 {
+			t.Errorf("keepCollinear %v: hull has %d bounded faces, want none", tt.keepCollinear, len(faces))
+		}
+		if len(d.Vertices) != len(tt.want) {
+			t.Fatalf("keepCollinear %v: structure has %d vertices, want %d", tt.keepCollinear, len(d.Vertices), len(tt.want))
+		}
+		for i, v := range d.Vertices {
+			if [2]int{v.X, v.Y} != tt.want[i] {
+				t.Errorf("keepCollinear %v: vertex %d is (%d, %d), want %v", tt.keepCollinear, i, v.X, v.Y, tt.want[i])
+			}
+		}
+		if len(d.HalfEdges) != 2*(len(tt.want)-1) {
+			t.Errorf("keepCollinear %v: structure has %d half-edges, want %d", tt.keepCollinear, len(d.HalfEdges), 2*(len(tt.want)-1))
+		}
+		for _, he := range d.HalfEdges {
+			if he.Face != d.OuterFace {
+				t.Errorf("keepCollinear %v: half-edge %v is not in the outer face", tt.keepCollinear, he)
+			}
+		}
+	}
+}
+
+func TestBuildConvexHullOptsSinglePoint(t *testing.T) {
+	d, err := BuildConvexHullOpts([][2]int{{3, 5}, {3, 5}}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Vertices) != 1 || len(d.HalfEdges) != 0 || len(d.boundedFaces()) != 0 {
+		t.Errorf("got %d vertices, %d half-edges and %d bounded faces, want a lone vertex",
+			len(d.Vertices), len(d.HalfEdges), len(d.boundedFaces()))
+	}
+	if _, err := BuildConvexHullOpts(nil, false); err == nil {
+		t.Error("no error for an empty input")
+	}
+}
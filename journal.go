@@ -0,0 +1,146 @@
+package dcel
+
+// journal records how to reverse the structural operations performed on a DCEL. Each entry holds
+// the undo functions of one operation, to be called in reverse order. Simple operations, like the
+// creation of a vertex, record their exact inverse, while operations that rewire many pointers
+// record a snapshot of the whole structure taken before they started. The undone operations are
+// kept as snapshots of the structure taken before undoing them, so they can be redone.
+type journal struct {
+	entries [][]func()
+	redo    []func()
+	current []func()
+	depth   int
+	// snapshot is true if the current operation already has a snapshot to restore, making the
+	// recording of finer grained inverses unnecessary.
+	snapshot bool
+}
+
+// BeginJournal starts recording the structural operations performed on the structure, like the
+// creation and removal of vertices, half-edges and faces, so they can be reversed with Undo and
+// performed again with Redo. Operations recorded before are discarded.
+func (d *DCEL) BeginJournal() {
+	d.journal = &journal{}
+}
+
+// EndJournal stops recording operations and discards the ones recorded so far.
+func (d *DCEL) EndJournal() {
+	d.journal = nil
+}
+
+// Undo reverses the last recorded operation that has not been undone yet. It does nothing if
+// journaling is not enabled or there is no operation left to undo. A snapshot of the structure is
+// taken first, so the operation can be redone, which makes Undo take time linear in the size of the
+// structure.
+func (d *DCEL) Undo() {
+	j := d.journal
+	if j == nil || j.depth > 0 || len(j.entries) == 0 {
+		return
+	}
+	entry := j.entries[len(j.entries)-1]
+	j.entries = j.entries[:len(j.entries)-1]
+	j.redo = append(j.redo, d.snapshot())
+	for i := len(entry) - 1; i >= 0; i-- {
+		entry[i]()
+	}
+}
+
+// Redo performs again the last operation reversed by Undo, restoring the structure to the state it
+// had after the operation. It does nothing if journaling is not enabled or there is no operation
+// left to redo. Recording a new operation discards the ones that can be redone.
+func (d *DCEL) Redo() {
+	j := d.journal
+	if j == nil || j.depth > 0 || len(j.redo) == 0 {
+		return
+	}
+	restore := j.redo[len(j.redo)-1]
+	j.redo = j.redo[:len(j.redo)-1]
+	j.entries = append(j.entries, []func(){d.snapshot()})
+	restore()
+}
+
+// beginOperation marks the start of an operation, so that everything recorded until the returned
+// function is called is undone as a single step. Operations can be nested, in which case they
+// become part of the outermost one. If snapshot is true, a snapshot of the structure is recorded
+// as the inverse of the operation.
+func (d *DCEL) beginOperation(snapshot bool) func() {
+	j := d.journal
+	if j == nil {
+		return func() {}
+	}
+	if snapshot && !j.snapshot {
+		j.current = append(j.current, d.snapshot())
+		j.snapshot = true
+	}
+	j.depth++
+	return func() {
+		j.depth--
+		if j.depth > 0 {
+			return
+		}
+		if len(j.current) > 0 {
+			j.entries = append(j.entries, j.current)
+			j.redo = nil
+		}
+		j.current, j.snapshot = nil, false
+	}
+}
+
+// recording returns true if the inverses of simple changes are recorded, i.e. if journaling is
+// enabled and the current operation has no snapshot. Callers check it before capturing the state
+// needed by an inverse, so nothing is allocated when it would be discarded.
+func (d *DCEL) recording() bool {
+	return d.journal != nil && !d.journal.snapshot
+}
+
+// record adds the inverse of a simple change to the journal, as part of the current operation or
+// as an operation of its own.
+func (d *DCEL) record(undo func()) {
+	if !d.recording() {
+		return
+	}
+	j := d.journal
+	if j.depth == 0 {
+		j.entries = append(j.entries, []func(){undo})
+		j.redo = nil
+		return
+	}
+	j.current = append(j.current, undo)
+}
+
+// snapshot copies the current state of all vertices, half-edges and faces in the structure and
+// returns a function that restores it. The InnerComponents of the faces are copied as well, as
+// some operations change them in place.
+func (d *DCEL) snapshot() func() {
+	vertices := append([]*Vertex(nil), d.Vertices...)
+	faces := append([]*Face(nil), d.Faces...)
+	halfEdges := append([]*HalfEdge(nil), d.HalfEdges...)
+	outer := d.OuterFace
+
+	vertexStates := make([]Vertex, len(vertices))
+	for i, v := range vertices {
+		vertexStates[i] = *v
+	}
+	faceStates := make([]Face, len(faces))
+	for i, f := range faces {
+		faceStates[i] = *f
+		faceStates[i].InnerComponents = append([]*HalfEdge(nil), f.InnerComponents...)
+	}
+	halfEdgeStates := make([]HalfEdge, len(halfEdges))
+	for i, he := range halfEdges {
+		halfEdgeStates[i] = *he
+	}
+
+	return func() {
+		for i, v := range vertices {
+			*v = vertexStates[i]
+		}
+		for i, f := range faces {
+			*f = faceStates[i]
+		}
+		for i, he := range halfEdges {
+			*he = halfEdgeStates[i]
+		}
+		d.Vertices, d.Faces, d.HalfEdges = vertices, faces, halfEdges
+		d.OuterFace = outer
+	}
+}
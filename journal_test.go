@@ -0,0 +1,268 @@
+package dcel
+
+import (
+	"reflect"
+	"testing"
+)
+
+// structureState is a copy of the elements of a structure and of the values stored in them, used
+// to tell whether Undo restored the structure exactly.
+type structureState struct {
+	vertices  []*Vertex
+	faces     []*Face
+	halfEdges []*HalfEdge
+	outer     *Face
+
+	vertexValues   []Vertex
+	faceValues     []Face
+	halfEdgeValues []HalfEdge
+}
+
+func stateOf(d *DCEL) structureState {
+	s := structureState{
+		vertices:  append([]*Vertex(nil), d.Vertices...),
+		faces:     append([]*Face(nil), d.Faces...),
+		halfEdges: append([]*HalfEdge(nil), d.HalfEdges...),
+		outer:     d.OuterFace,
+	}
+	for _, v := range d.Vertices {
+		s.vertexValues = append(s.vertexValues, *v)
+	}
+	for _, f := range d.Faces {
+		// The holes are copied, as operations may change them in place
+		value := *f
+		value.InnerComponents = append([]*HalfEdge(nil), f.InnerComponents...)
+		s.faceValues = append(s.faceValues, value)
+	}
+	for _, he := range d.HalfEdges {
+		s.halfEdgeValues = append(s.halfEdgeValues, *he)
+	}
+	return s
+}
+
+// assertRestored fails the test if the structure differs from the state taken before.
+func assertRestored(t *testing.T, d *DCEL, before structureState) {
+	t.Helper()
+	after := stateOf(d)
+	switch {
+	case !reflect.DeepEqual(after.vertices, before.vertices):
+		t.Errorf("vertices are %v, want %v", after.vertices, before.vertices)
+	case !reflect.DeepEqual(after.faces, before.faces):
+		t.Errorf("faces are %v, want %v", after.faces, before.faces)
+	case !reflect.DeepEqual(after.halfEdges, before.halfEdges):
+		t.Errorf("half-edges are %v, want %v", after.halfEdges, before.halfEdges)
+	case after.outer != before.outer:
+		t.Errorf("outer face is %p, want %p", after.outer, before.outer)
+	}
+	for i := range before.vertexValues {
+		if i < len(after.vertexValues) && after.vertexValues[i] != before.vertexValues[i] {
+			t.Errorf("vertex %d is %v, want %v", i, after.vertexValues[i], before.vertexValues[i])
+		}
+	}
+	for i := range before.faceValues {
+		if i < len(after.faceValues) && !reflect.DeepEqual(after.faceValues[i], before.faceValues[i]) {
+			t.Errorf("face %d is %+v, want %+v", i, after.faceValues[i], before.faceValues[i])
+		}
+	}
+	for i := range before.halfEdgeValues {
+		if i < len(after.halfEdgeValues) && after.halfEdgeValues[i] != before.halfEdgeValues[i] {
+			t.Errorf("half-edge %d is %+v, want %+v", i, after.halfEdgeValues[i], before.halfEdgeValues[i])
+		}
+	}
+}
+
+// journaledGrid returns a 2x2 grid of 10x10 cells with journaling enabled.
+func journaledGrid() *DCEL {
+	d := BuildGrid(2, 2, 10, 10)
+	d.BeginJournal()
+	return d
+}
+
+func TestUndoNewVertex(t *testing.T) {
+	d := journaledGrid()
+	before := stateOf(d)
+	d.NewVertex(30, 30)
+	d.Undo()
+	assertRestored(t, d, before)
+}
+
+func TestUndoNewFace(t *testing.T) {
+	d := journaledGrid()
+	before := stateOf(d)
+	d.NewFace()
+	d.Undo()
+	assertRestored(t, d, before)
+}
+
+func TestUndoNewHalfEdge(t *testing.T) {
+	d := BuildGrid(2, 2, 10, 10)
+	v := d.NewVertex(30, 30)
+	d.BeginJournal()
+	before := stateOf(d)
+	// The face already has a boundary, so the new half-edge is linked into it, and the vertex has
+	// no half-edge yet, so it is linked to the new one
+	d.NewHalfEdge(d.boundedFaces()[0], v, nil)
+	d.Undo()
+	assertRestored(t, d, before)
+}
+
+func TestUndoNewEdge(t *testing.T) {
+	d := BuildGrid(2, 2, 10, 10)
+	v := d.NewVertex(30, 30)
+	d.BeginJournal()
+	before := stateOf(d)
+	d.NewEdge(d.boundedFaces()[0], d.OuterFace, v)
+	// Both half-edges are undone as a single operation
+	d.Undo()
+	assertRestored(t, d, before)
+}
+
+func TestUndoMoveVertex(t *testing.T) {
+	d := journaledGrid()
+	var center *Vertex
+	for _, v := range d.Vertices {
+		if v.X == 10 && v.Y == 10 {
+			center = v
+		}
+	}
+	before := stateOf(d)
+	if err := d.MoveVertex(center, 12, 9); err != nil {
+		t.Fatal(err)
+	}
+	d.Undo()
+	assertRestored(t, d, before)
+}
+
+func TestUndoSplitEdge(t *testing.T) {
+	d := journaledGrid()
+	before := stateOf(d)
+	he := d.boundedFaces()[0].HalfEdge
+	origin := he.Origin()
+	d.SplitEdge(he, (origin.X+he.Target.X)/2, (origin.Y+he.Target.Y)/2)
+	d.Undo()
+	assertRestored(t, d, before)
+}
+
+func TestUndoRemoveEdge(t *testing.T) {
+	d := journaledGrid()
+	before := stateOf(d)
+	if err := d.RemoveEdge(d.InteriorEdges()[0]); err != nil {
+		t.Fatal(err)
+	}
+	d.Undo()
+	assertRestored(t, d, before)
+}
+
+func TestUndoSequence(t *testing.T) {
+	d := journaledGrid()
+	states := []structureState{stateOf(d)}
+	d.NewVertex(30, 30)
+	states = append(states, stateOf(d))
+	if err := d.RemoveEdge(d.InteriorEdges()[0]); err != nil {
+		t.Fatal(err)
+	}
+	states = append(states, stateOf(d))
+	d.NewFace()
+	for i := len(states) - 1; i >= 0; i-- {
+		d.Undo()
+		assertRestored(t, d, states[i])
+	}
+	// Nothing is left to undo
+	d.Undo()
+	assertRestored(t, d, states[0])
+}
+
+func TestUndoNestedOperations(t *testing.T) {
+	d := journaledGrid()
+	before := stateOf(d)
+	end := d.beginOperation(false)
+	v := d.NewVertex(30, 30)
+	d.NewEdge(d.boundedFaces()[0], d.OuterFace, v)
+	// A snapshot operation nested in an operation of simple changes
+	he := d.boundedFaces()[1].HalfEdge
+	origin := he.Origin()
+	d.SplitEdge(he, (origin.X+he.Target.X)/2, (origin.Y+he.Target.Y)/2)
+	d.NewFace()
+	end()
+	d.Undo()
+	assertRestored(t, d, before)
+}
+
+func TestNoRecordingWithoutJournal(t *testing.T) {
+	d := BuildGrid(2, 2, 10, 10)
+	before := stateOf(d)
+	d.NewVertex(30, 30)
+	d.Undo()
+	if len(d.Vertices) != len(before.vertices)+1 {
+		t.Errorf("Undo without a journal changed the structure")
+	}
+	if allocs := testing.AllocsPerRun(100, func() { d.NewVertex(30, 30) }); allocs > 1 {
+		t.Errorf("NewVertex without a journal allocates %v times, want at most 1", allocs)
+	}
+}
+
+func TestUndoRestoresHoles(t *testing.T) {
+	// A region with a hole of two cells, whose boundary is split at (2, 1)
+	d, err := FromRaster([][]bool{
+		{true, true, true, true},
+		{true, false, false, true},
+		{true, true, true, true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var region *Face
+	for _, f := range d.boundedFaces() {
+		region = f
+	}
+	hole := region.InnerComponents[0]
+	for hole.Target.X != 1 || hole.Target.Y != 1 {
+		hole = hole.Next
+	}
+	// (1, 1) is reached along the bottom of the hole, from (3, 1)
+	d.SplitEdge(hole, 2, 1)
+	next := hole.Next
+	region.InnerComponents[0] = next
+
+	d.BeginJournal()
+	before := stateOf(d)
+	// Dissolving the vertex at (2, 1) replaces the hole reference in place
+	d.SimplifyCollinear(0)
+	if region.InnerComponents[0] == next {
+		t.Fatal("SimplifyCollinear did not change the hole reference")
+	}
+	d.Undo()
+	assertRestored(t, d, before)
+}
+
+func TestRedo(t *testing.T) {
+	d := journaledGrid()
+	states := []structureState{stateOf(d)}
+	d.NewVertex(30, 30)
+	states = append(states, stateOf(d))
+	if err := d.RemoveEdge(d.InteriorEdges()[0]); err != nil {
+		t.Fatal(err)
+	}
+	states = append(states, stateOf(d))
+
+	d.Undo()
+	d.Undo()
+	assertRestored(t, d, states[0])
+	d.Redo()
+	assertRestored(t, d, states[1])
+	d.Redo()
+	assertRestored(t, d, states[2])
+	// Nothing is left to redo
+	d.Redo()
+	assertRestored(t, d, states[2])
+
+	// Redone operations can be undone again
+	d.Undo()
+	assertRestored(t, d, states[1])
+
+	// A new operation discards the ones that could be redone
+	d.NewFace()
+	d.Redo()
+	d.Undo()
+	assertRestored(t, d, states[1])
+}
@@ -0,0 +1,280 @@
+package dcel
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
+// InteriorPoint returns a point inside the face that is suitable for placing a label, unlike the
+// centroid, which can fall outside of concave faces or inside one of their holes. The point is
+// found with the polylabel algorithm, which approximates the pole of inaccessibility: the point
+// inside the face that is farthest from its boundary, including the boundaries of its holes. The
+// bounding box of the face is covered with square cells, and the cells that may contain a point
+// farther from the boundary than the best one found so far are split into quarters, until no
+// cell can improve it by more than a thousandth of the shorter side of the box.
+//
+// The search starts from the midpoint of the widest interval of a horizontal line crossing the
+// face between two vertex levels, so the returned point lies strictly inside the face, off its
+// boundary and its holes, for any face with a closed boundary and a positive area. Otherwise the
+// centroid of the face is returned.
+func (f *Face) InteriorPoint() (float64, float64) {
+	outer := f.boundary()
+	if len(outer) == 0 {
+		return f.Centroid()
+	}
+	rings := [][]*HalfEdge{outer}
+	for _, hole := range f.InnerComponents {
+		if edges := closedCycle(hole); len(edges) > 0 {
+			rings = append(rings, edges)
+		}
+	}
+	x, y, ok := scanlinePoint(rings)
+	if !ok {
+		return f.Centroid()
+	}
+
+	minX, minY, maxX, maxY := f.BoundingBox()
+	w, h := float64(maxX-minX), float64(maxY-minY)
+	size := min(w, h)
+	precision := size / 1000
+	cell := func(x, y, half float64) labelCell {
+		d := signedDistance(rings, x, y)
+		return labelCell{x: x, y: y, half: half, dist: d, max: d + half*math.Sqrt2}
+	}
+	best := cell(x, y, 0)
+	queue := &labelQueue{}
+	for cx := float64(minX); cx < float64(maxX); cx += size {
+		for cy := float64(minY); cy < float64(maxY); cy += size {
+			heap.Push(queue, cell(cx+size/2, cy+size/2, size/2))
+		}
+	}
+	cx, cy := f.Centroid()
+	heap.Push(queue, cell(cx, cy, 0))
+
+	for queue.Len() > 0 {
+		c := heap.Pop(queue).(labelCell)
+		if c.dist > best.dist {
+			best = c
+		}
+		if c.max-best.dist <= precision {
+			continue
+		}
+		half := c.half / 2
+		heap.Push(queue, cell(c.x-half, c.y-half, half))
+		heap.Push(queue, cell(c.x+half, c.y-half, half))
+		heap.Push(queue, cell(c.x-half, c.y+half, half))
+		heap.Push(queue, cell(c.x+half, c.y+half, half))
+	}
+	return best.x, best.y
+}
+
+// LargestInscribedRect returns the lower-left corner, width and height of an approximation of the
+// largest axis-aligned rectangle that fits inside the face and outside its holes. The bounding box
+// of the face is divided into square cells whose sides are resolution long, starting from its
+// lower-left corner, and the rectangle is the largest one made of cells that lie entirely inside
+// the face, found with the maximal rectangle algorithm for histograms. The result is therefore
+// aligned to that grid and at most one cell smaller on each side than the true maximum; a smaller
+// resolution gives a better approximation at the cost of more cells to test. A resolution less
+// than one is treated as one. All four values are zero if no cell fits inside the face, or if the
+// face has no closed boundary.
+func (f *Face) LargestInscribedRect(resolution int) (x, y, w, h int) {
+	outer := f.boundary()
+	if len(outer) == 0 {
+		return 0, 0, 0, 0
+	}
+	rings := [][]*HalfEdge{outer}
+	for _, hole := range f.InnerComponents {
+		if edges := closedCycle(hole); len(edges) > 0 {
+			rings = append(rings, edges)
+		}
+	}
+	step := max(resolution, 1)
+	minX, minY, maxX, maxY := f.BoundingBox()
+	cols, rows := (maxX-minX)/step, (maxY-minY)/step
+	if cols == 0 || rows == 0 {
+		return 0, 0, 0, 0
+	}
+
+	// A cell is blocked if an edge passes through its interior, otherwise it lies inside or outside
+	// of the face as a whole, which is told by its center
+	blocked := make([][]bool, rows)
+	for j := range blocked {
+		blocked[j] = make([]bool, cols)
+	}
+	for _, ring := range rings {
+		for i, he := range ring {
+			a, b := ring[(i+len(ring)-1)%len(ring)].Target, he.Target
+			i0, i1 := max((min(a.X, b.X)-minX)/step-1, 0), min((max(a.X, b.X)-minX)/step, cols-1)
+			j0, j1 := max((min(a.Y, b.Y)-minY)/step-1, 0), min((max(a.Y, b.Y)-minY)/step, rows-1)
+			for j := j0; j <= j1; j++ {
+				for i := i0; i <= i1; i++ {
+					cx, cy := minX+i*step, minY+j*step
+					if !blocked[j][i] && segmentEntersRect(a, b, cx, cy, cx+step, cy+step) {
+						blocked[j][i] = true
+					}
+				}
+			}
+		}
+	}
+
+	heights := make([]int, cols)
+	best := 0
+	for j := 0; j < rows; j++ {
+		for i := 0; i < cols; i++ {
+			center := &Vertex{X: 2*(minX+i*step) + step, Y: 2*(minY+j*step) + step}
+			if !blocked[j][i] && f.classifyScaled(outer, center, 2) > 0 {
+				heights[i]++
+			} else {
+				heights[i] = 0
+			}
+		}
+		// The largest rectangle under the histogram of the column heights ending at this row
+		var stack []int
+		for i := 0; i <= cols; i++ {
+			height := 0
+			if i < cols {
+				height = heights[i]
+			}
+			for len(stack) > 0 && heights[stack[len(stack)-1]] >= height {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				left := 0
+				if len(stack) > 0 {
+					left = stack[len(stack)-1] + 1
+				}
+				if area := heights[top] * (i - left); area > best {
+					best = area
+					x, y = minX+left*step, minY+(j-heights[top]+1)*step
+					w, h = (i-left)*step, heights[top]*step
+				}
+			}
+			stack = append(stack, i)
+		}
+	}
+	return x, y, w, h
+}
+
+// segmentEntersRect returns true if some part of the segment ab lies strictly inside the rectangle
+// from (minX, minY) to (maxX, maxY), not only on its sides.
+func segmentEntersRect(a, b *Vertex, minX, minY, maxX, maxY int) bool {
+	// Clip the segment to the closed rectangle with the Liang-Barsky algorithm. The clipped part
+	// is convex, so if any of it lies inside, all of it but its endpoints does, like its midpoint.
+	t0, t1 := 0.0, 1.0
+	dx, dy := float64(b.X-a.X), float64(b.Y-a.Y)
+	for _, c := range [][2]float64{
+		{-dx, float64(a.X - minX)}, {dx, float64(maxX - a.X)},
+		{-dy, float64(a.Y - minY)}, {dy, float64(maxY - a.Y)},
+	} {
+		p, q := c[0], c[1]
+		switch {
+		case p == 0:
+			if q < 0 {
+				return false
+			}
+		case p < 0:
+			t0 = max(t0, q/p)
+		default:
+			t1 = min(t1, q/p)
+		}
+	}
+	if t0 > t1 {
+		return false
+	}
+	t := (t0 + t1) / 2
+	x, y := float64(a.X)+t*dx, float64(a.Y)+t*dy
+	return float64(minX) < x && x < float64(maxX) && float64(minY) < y && y < float64(maxY)
+}
+
+// scanlinePoint returns the midpoint of the widest interval in which a horizontal line, halfway
+// between the two middle levels of the vertices, lies inside the given closed rings under the
+// even-odd rule. It returns false if all vertices lie on the same level.
+func scanlinePoint(rings [][]*HalfEdge) (float64, float64, bool) {
+	levels := make(map[int]bool)
+	for _, ring := range rings {
+		for _, he := range ring {
+			levels[he.Target.Y] = true
+		}
+	}
+	ys := make([]int, 0, len(levels))
+	for y := range levels {
+		ys = append(ys, y)
+	}
+	if len(ys) < 2 {
+		return 0, 0, false
+	}
+	sort.Ints(ys)
+	i := len(ys) / 2
+	y := float64(ys[i-1]+ys[i]) / 2
+
+	// No vertex lies on the line, so every edge crossing it does so at a single interior point
+	var xs []float64
+	for _, ring := range rings {
+		for k, he := range ring {
+			a, b := ring[(k+len(ring)-1)%len(ring)].Target, he.Target
+			if (float64(a.Y) < y) != (float64(b.Y) < y) {
+				t := (y - float64(a.Y)) / float64(b.Y-a.Y)
+				xs = append(xs, float64(a.X)+t*float64(b.X-a.X))
+			}
+		}
+	}
+	sort.Float64s(xs)
+	best := -1
+	for j := 0; j+1 < len(xs); j += 2 {
+		if best < 0 || xs[j+1]-xs[j] > xs[best+1]-xs[best] {
+			best = j
+		}
+	}
+	if best < 0 {
+		return 0, 0, false
+	}
+	return (xs[best] + xs[best+1]) / 2, y, true
+}
+
+// signedDistance returns the distance from the point (x, y) to the closest edge of the given
+// closed rings, positive if the point lies inside them under the even-odd rule and negative
+// otherwise.
+func signedDistance(rings [][]*HalfEdge, x, y float64) float64 {
+	inside := false
+	dist := math.Inf(1)
+	for _, ring := range rings {
+		for k, he := range ring {
+			a, b := ring[(k+len(ring)-1)%len(ring)].Target, he.Target
+			if (float64(a.Y) > y) != (float64(b.Y) > y) {
+				t := (y - float64(a.Y)) / float64(b.Y-a.Y)
+				if x < float64(a.X)+t*float64(b.X-a.X) {
+					inside = !inside
+				}
+			}
+			px, py := closestOnSegment(x, y, a, b)
+			dist = min(dist, math.Hypot(x-px, y-py))
+		}
+	}
+	if !inside {
+		return -dist
+	}
+	return dist
+}
+
+// labelCell is a square cell of the InteriorPoint search, centered at (x, y) with half the side
+// length in half. Its center is dist away from the boundary, and no point in it is farther than
+// max.
+type labelCell struct {
+	x, y, half float64
+	dist, max  float64
+}
+
+// labelQueue is a max-heap of cells ordered by the largest distance they can contain, implementing
+// heap.Interface.
+type labelQueue []labelCell
+
+func (q labelQueue) Len() int            { return len(q) }
+func (q labelQueue) Less(i, j int) bool  { return q[i].max > q[j].max }
+func (q labelQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *labelQueue) Push(x interface{}) { *q = append(*q, x.(labelCell)) }
+func (q *labelQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,121 @@
+package dcel
+
+import (
+	"errors"
+	"math"
+)
+
+// LloydRelax spreads the vertices of the structure evenly with the given number of iterations of
+// Lloyd's algorithm. In every iteration, the vertices are treated as the sites of a Voronoi
+// diagram, which is derived from their Delaunay triangulation built with
+// BuildConstrainedDelaunay, and every vertex is moved to the centroid of its Voronoi cell, rounded
+// to integer coordinates. Cells are clipped to the bounding box of the vertices before the first
+// iteration, so the cells of the sites on the hull stay finite and the vertices never leave the
+// box. Only the coordinates of the vertices change: edges and faces follow their vertices, so the
+// method is meant for structures used as point sets, as moving the vertices may break the
+// planarity of a subdivision. All iterations are computed before any vertex is moved, and the
+// structure is left untouched if an error is returned: if the triangulation can not be built, e.g.
+// because there are less than three distinct vertices or all of them are collinear, or if rounding
+// would move two vertices at different coordinates to the same point.
+func (d *DCEL) LloydRelax(iterations int) error {
+	if iterations <= 0 || len(d.Vertices) == 0 {
+		return nil
+	}
+	defer d.beginOperation(true)()
+	minX, minY, maxX, maxY := d.Vertices[0].X, d.Vertices[0].Y, d.Vertices[0].X, d.Vertices[0].Y
+	for _, v := range d.Vertices {
+		minX, minY = min(minX, v.X), min(minY, v.Y)
+		maxX, maxY = max(maxX, v.X), max(maxY, v.Y)
+	}
+	box := [][2]float64{
+		{float64(minX), float64(minY)}, {float64(maxX), float64(minY)},
+		{float64(maxX), float64(maxY)}, {float64(minX), float64(maxY)},
+	}
+
+	points := make([][2]int, len(d.Vertices))
+	for j, v := range d.Vertices {
+		points[j] = [2]int{v.X, v.Y}
+	}
+	distinct := countDistinct(points)
+	for i := 0; i < iterations; i++ {
+		t, err := BuildConstrainedDelaunay(points, nil)
+		if err != nil {
+			return err
+		}
+		sites := make(map[[2]int]*Vertex, len(t.Vertices))
+		for _, v := range t.Vertices {
+			sites[[2]int{v.X, v.Y}] = v
+		}
+		adj := t.adjacency()
+
+		moved := make([][2]int, len(d.Vertices))
+		for j, p := range points {
+			site := sites[p]
+			cell := box
+			for _, he := range adj[site] {
+				// Keep the part of the cell closer to the site than to its neighbour
+				n := otherEnd(he, site)
+				nx, ny := float64(n.X-site.X), float64(n.Y-site.Y)
+				mx, my := float64(n.X+site.X)/2, float64(n.Y+site.Y)/2
+				cell = clipHalfPlane(cell, nx, ny, nx*mx+ny*my)
+			}
+			cx, cy, ok := polygonCentroid(cell)
+			if !ok {
+				moved[j] = p
+				continue
+			}
+			moved[j] = [2]int{int(math.Round(cx)), int(math.Round(cy))}
+		}
+		if countDistinct(moved) < distinct {
+			return errors.New("relaxed vertices would coincide")
+		}
+		points = moved
+	}
+	for j, v := range d.Vertices {
+		v.X, v.Y = points[j][0], points[j][1]
+	}
+	return nil
+}
+
+// countDistinct returns the number of distinct points in the slice.
+func countDistinct(points [][2]int) int {
+	seen := make(map[[2]int]bool, len(points))
+	for _, p := range points {
+		seen[p] = true
+	}
+	return len(seen)
+}
+
+// clipHalfPlane returns the part of the convex polygon where nx*x + ny*y <= c.
+func clipHalfPlane(polygon [][2]float64, nx, ny, c float64) [][2]float64 {
+	var clipped [][2]float64
+	for i, p := range polygon {
+		q := polygon[(i+1)%len(polygon)]
+		dp, dq := nx*p[0]+ny*p[1]-c, nx*q[0]+ny*q[1]-c
+		if dp <= 0 {
+			clipped = append(clipped, p)
+		}
+		if dp < 0 && dq > 0 || dp > 0 && dq < 0 {
+			t := dp / (dp - dq)
+			clipped = append(clipped, [2]float64{p[0] + t*(q[0]-p[0]), p[1] + t*(q[1]-p[1])})
+		}
+	}
+	return clipped
+}
+
+// polygonCentroid returns the center of mass of the polygon, or false as the last result if the
+// polygon has zero area.
+func polygonCentroid(polygon [][2]float64) (float64, float64, bool) {
+	var cx, cy, sum float64
+	for i, p := range polygon {
+		q := polygon[(i+1)%len(polygon)]
+		cross := p[0]*q[1] - q[0]*p[1]
+		cx += (p[0] + q[0]) * cross
+		cy += (p[1] + q[1]) * cross
+		sum += cross
+	}
+	if sum == 0 {
+		return 0, 0, false
+	}
+	return cx / (3 * sum), cy / (3 * sum), true
+}
@@ -0,0 +1,109 @@
+package dcel
+
+import "math"
+
+// MedialAxis returns an approximation of the medial axis of the region covered by the bounded
+// faces, i.e. of the points inside the region with more than one closest point on its boundary.
+// The boundary of the region, made of the edges on the outer boundary, is sampled with points at
+// most spacing apart, including the vertices. The approximation is made of the edges of the
+// Voronoi diagram of the samples that lie inside the region and separate samples that are not next
+// to each other on the boundary, as the other Voronoi edges run towards the boundary itself. The
+// denser the samples, the closer the approximation and the more edges it has.
+//
+// The Voronoi diagram is derived from the Delaunay triangulation of the samples, built with
+// BuildConstrainedDelaunay, and its vertices are rounded to integer coordinates. The returned
+// half-edges, one for each edge of the axis, are not part of the structure: together with their
+// twins and target vertices they form a separate graph without faces, in which edges meeting at
+// the same point share their vertex. Nil is returned if OuterFace is not set, if spacing is not
+// positive or if there are not enough samples to build the diagram.
+func (d *DCEL) MedialAxis(spacing int) []*HalfEdge {
+	if d.OuterFace == nil || spacing <= 0 {
+		return nil
+	}
+
+	// Sample the boundary, numbering the samples along each edge so neighbours can be told apart
+	type sample struct {
+		edge, index, count int
+	}
+	samples := make(map[[2]int][]sample)
+	var points [][2]int
+	addSample := func(p [2]int, s sample) {
+		if _, ok := samples[p]; !ok {
+			points = append(points, p)
+		}
+		samples[p] = append(samples[p], s)
+	}
+	boundary := 0
+	for _, he := range d.edges() {
+		a, b := he.Origin(), he.Target
+		if a == nil || b == nil || !he.IsBoundary() {
+			continue
+		}
+		n := max(1, int(math.Ceil(he.Length()/float64(spacing))))
+		for i := 0; i <= n; i++ {
+			t := float64(i) / float64(n)
+			p := [2]int{
+				int(math.Round(float64(a.X) + t*float64(b.X-a.X))),
+				int(math.Round(float64(a.Y) + t*float64(b.Y-a.Y))),
+			}
+			addSample(p, sample{boundary, i, n})
+		}
+		boundary++
+	}
+	neighbours := func(p, q [2]int) bool {
+		for _, s := range samples[p] {
+			for _, r := range samples[q] {
+				if s.edge == r.edge && (s.index-r.index == 1 || r.index-s.index == 1) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	t, err := BuildConstrainedDelaunay(points, nil)
+	if err != nil {
+		return nil
+	}
+
+	inside := func(x, y int) bool {
+		for _, f := range d.boundedFaces() {
+			if f.Contains(x, y) {
+				return true
+			}
+		}
+		return false
+	}
+	centers := make(map[*Face][2]int)
+	for _, f := range t.boundedFaces() {
+		if x, y, _, err := f.Circumcircle(); err == nil {
+			if p := [2]int{int(math.Round(x)), int(math.Round(y))}; inside(p[0], p[1]) {
+				centers[f] = p
+			}
+		}
+	}
+
+	axis := NewDCEL()
+	vertexAt := axis.vertexIndex()
+	added := make(map[[2]*Vertex]bool)
+	var edges []*HalfEdge
+	for _, he := range t.InteriorEdges() {
+		a, b := he.Origin(), he.Target
+		if neighbours([2]int{a.X, a.Y}, [2]int{b.X, b.Y}) {
+			continue
+		}
+		p, ok1 := centers[he.Face]
+		q, ok2 := centers[he.Twin.Face]
+		if !ok1 || !ok2 || p == q {
+			continue
+		}
+		u, v := vertexAt(p[0], p[1]), vertexAt(q[0], q[1])
+		if added[[2]*Vertex{u, v}] {
+			continue
+		}
+		added[[2]*Vertex{u, v}], added[[2]*Vertex{v, u}] = true, true
+		e, twin := axis.addHalfEdge(nil, v), axis.addHalfEdge(nil, u)
+		e.Twin, twin.Twin = twin, e
+		edges = append(edges, e)
+	}
+	return edges
+}
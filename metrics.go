@@ -0,0 +1,90 @@
+package dcel
+
+import "math"
+
+// AngleStats returns the minimum, maximum and average interior angle (in radians) over all
+// triangular faces of the structure. Faces that are not triangles and the outer face are skipped.
+// All three values are zero if the structure contains no triangles.
+func (d *DCEL) AngleStats() (minAngle, maxAngle, avgAngle float64) {
+	minAngle = math.Inf(1)
+	maxAngle = math.Inf(-1)
+	count := 0
+	for _, face := range d.boundedFaces() {
+		edges := face.boundary()
+		if len(edges) != 3 {
+			continue
+		}
+		for _, he := range edges {
+			angle := he.InteriorAngle()
+			minAngle = math.Min(minAngle, angle)
+			maxAngle = math.Max(maxAngle, angle)
+			avgAngle += angle
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, 0, 0
+	}
+	return minAngle, maxAngle, avgAngle / float64(count)
+}
+
+// TotalEdgeLength returns the sum of the lengths of all edges in the structure, counting each pair
+// of twin half-edges once.
+func (d *DCEL) TotalEdgeLength() float64 {
+	var total float64
+	for _, he := range d.edges() {
+		total += he.Length()
+	}
+	return total
+}
+
+// FaceDegreeHistogram returns the number of bounded faces for each face degree, i.e. for each
+// count of boundary half-edges. The outer face is excluded.
+func (d *DCEL) FaceDegreeHistogram() map[int]int {
+	histogram := make(map[int]int)
+	for _, face := range d.boundedFaces() {
+		histogram[face.Degree()]++
+	}
+	return histogram
+}
+
+// EulerCharacteristic returns V - E + F for the vertices, edges and faces stored in the structure,
+// where an edge is a pair of twin half-edges or a half-edge without a twin. For a connected planar
+// subdivision that stores its outer face the result is 2, while a subdivision made of several
+// components sharing one outer face gives 1 plus the number of components.
+func (d *DCEL) EulerCharacteristic() int {
+	return len(d.Vertices) - len(d.edges()) + len(d.Faces)
+}
+
+// SumSignedAreas returns the sum of the signed areas of all bounded faces, each including the
+// signed areas of its holes, which are oriented opposite to it. In a consistently oriented
+// subdivision it equals the area enclosed by the outer boundary, with the sign of the orientation
+// of the faces, so any other value reveals faces oriented the wrong way.
+func (d *DCEL) SumSignedAreas() float64 {
+	var sum float64
+	for _, face := range d.boundedFaces() {
+		sum += face.Area()
+		for _, hole := range face.InnerComponents {
+			if edges := closedCycle(hole); len(edges) > 0 {
+				sum += ringArea(edges)
+			}
+		}
+	}
+	return sum
+}
+
+// TotalArea returns the area covered by the bounded faces of the structure, i.e. the sum of the
+// absolute areas enclosed by their outer boundaries, minus the areas of the holes given by their
+// inner components. Holes without a closed boundary are ignored.
+func (d *DCEL) TotalArea() float64 {
+	var total float64
+	for _, face := range d.boundedFaces() {
+		total += math.Abs(face.Area())
+		for _, hole := range face.InnerComponents {
+			if edges := closedCycle(hole); len(edges) > 0 {
+				total -= math.Abs(ringArea(edges))
+			}
+		}
+	}
+	return total
+}
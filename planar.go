@@ -0,0 +1,169 @@
+package dcel
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"sort"
+)
+
+// PlanarizeEdges removes all crossings between the edges of the structure. Edges are split at
+// every point where they cross or touch another edge, inserting a vertex there, overlapping pieces
+// of collinear edges are merged into one, and the faces are then rebuilt with RebuildFaces.
+//
+// The crossings are found with the Bentley-Ottmann algorithm, which takes O((n + k) log n) time
+// for n edges and k points where edges meet, computing the points exactly. As coordinates are
+// integers, a crossing point is then rounded to the nearest integer point, reusing an existing
+// vertex at that point if there is one. Moving a crossing point can make the pieces of a split
+// edge cross edges they did not cross before, so the sweep is repeated on the split edges until it
+// finds no crossing, which makes the result pass CheckPlanarity. Each repetition only splits edges
+// at integer points within half a unit of them in each coordinate, so the sweep usually settles
+// after one or two repetitions. An error is returned if a half-edge has no twin or no target
+// vertex.
+func (d *DCEL) PlanarizeEdges() error {
+	defer d.beginOperation(true)()
+	if err := d.planarize(); err != nil {
+		return err
+	}
+	return d.RebuildFaces()
+}
+
+// planarize splits the edges of the structure at their crossings and removes the redundant ones
+// like PlanarizeEdges, but does not rebuild the faces. The pieces of a split half-edge keep its
+// face.
+func (d *DCEL) planarize() error {
+	for _, he := range d.HalfEdges {
+		if he.Twin == nil || he.Target == nil || he.Twin.Target == nil {
+			return errors.New("half-edge has no twin or target vertex")
+		}
+	}
+
+	vertexAt := d.vertexIndex()
+	// Rounded crossing points can introduce new crossings, so repeat until none is found
+	for d.splitCrossings(vertexAt) {
+		d.removeRedundantEdges()
+	}
+	d.removeRedundantEdges()
+	return nil
+}
+
+// vertexIndex returns a function that returns the vertex of the structure at the given
+// coordinates, creating it with NewVertex if there is none, so that every point becomes a single
+// vertex. The index is seeded with the vertices already in the structure, and only knows about
+// the vertices created through the returned function afterwards.
+func (d *DCEL) vertexIndex() func(x, y int) *Vertex {
+	byCoords := make(map[[2]int]*Vertex, len(d.Vertices))
+	for _, v := range d.Vertices {
+		byCoords[[2]int{v.X, v.Y}] = v
+	}
+	return func(x, y int) *Vertex {
+		if v, ok := byCoords[[2]int{x, y}]; ok {
+			return v
+		}
+		v := d.NewVertex(x, y)
+		byCoords[[2]int{x, y}] = v
+		return v
+	}
+}
+
+// splitCrossings splits every edge of the structure at the points where it crosses or touches
+// another edge, as found by a single sweep with sweepIntersections, creating the vertices at the
+// rounded crossing points with vertexAt. It returns true if any edge was split.
+func (d *DCEL) splitCrossings(vertexAt func(x, y int) *Vertex) bool {
+	edges := d.edges()
+	splits := make(map[*HalfEdge][]*Vertex)
+	sweepIntersections(edges, func(x, y *big.Rat, interior []*HalfEdge) {
+		fx, _ := x.Float64()
+		fy, _ := y.Float64()
+		v := vertexAt(int(math.Round(fx)), int(math.Round(fy)))
+		for _, he := range interior {
+			if v != he.Target && v != he.Origin() {
+				splits[he] = append(splits[he], v)
+			}
+		}
+	})
+	for _, he := range edges {
+		d.splitEdgeAtAll(he, splits[he])
+	}
+	return len(splits) > 0
+}
+
+// splitEdgeAtAll splits the edge of he at each of the given vertices, which must lie on it and be
+// stored in the structure already. The vertices may be given in any order and may repeat.
+func (d *DCEL) splitEdgeAtAll(he *HalfEdge, vertices []*Vertex) {
+	if len(vertices) == 0 {
+		return
+	}
+	origin := he.Origin()
+	dist := func(v *Vertex) int64 {
+		return int64(v.X-origin.X)*int64(he.Target.X-origin.X) + int64(v.Y-origin.Y)*int64(he.Target.Y-origin.Y)
+	}
+	sort.Slice(vertices, func(i, j int) bool { return dist(vertices[i]) < dist(vertices[j]) })
+	current := he
+	for i, v := range vertices {
+		if i > 0 && v == vertices[i-1] {
+			continue
+		}
+		d.splitEdgeAt(current, v)
+		current = current.Next
+	}
+}
+
+// crossingPoint returns the point where the segments ab and cd cross, rounded to integer
+// coordinates. The segments must not be parallel.
+func crossingPoint(a, b, c, d *Vertex) (int, int) {
+	rx, ry := int64(b.X-a.X), int64(b.Y-a.Y)
+	sx, sy := int64(d.X-c.X), int64(d.Y-c.Y)
+	denom := rx*sy - ry*sx
+	num := int64(c.X-a.X)*sy - int64(c.Y-a.Y)*sx
+	t := float64(num) / float64(denom)
+	return int(math.Round(float64(a.X) + t*float64(rx))), int(math.Round(float64(a.Y) + t*float64(ry)))
+}
+
+// removeRedundantEdges removes edges whose endpoints coincide and all but one of the edges that
+// connect the same pair of vertices.
+func (d *DCEL) removeRedundantEdges() {
+	removed := make(map[*HalfEdge]bool)
+	seen := make(map[[2]*Vertex]bool)
+	for _, he := range d.edges() {
+		a, b := he.Origin(), he.Target
+		if a == b || seen[[2]*Vertex{a, b}] {
+			removed[he] = true
+			if he.Twin != nil {
+				removed[he.Twin] = true
+			}
+			continue
+		}
+		seen[[2]*Vertex{a, b}], seen[[2]*Vertex{b, a}] = true, true
+	}
+	d.remove(nil, removed, nil)
+}
+
+// BuildArrangement builds the planar subdivision induced by the given segments, each given as
+// [x1, y1, x2, y2]. Segments are split at every point where they cross or touch each other, which
+// becomes a vertex, overlapping segments are merged, and the faces are derived with PlanarizeEdges,
+// whose Bentley-Ottmann sweep finds the k meeting points of n segments in O((n + k) log n) time.
+// The boundary enclosing the largest area is stored as OuterFace, while the boundary around every
+// other connected group of segments becomes one of the InnerComponents of the face it lies in, or
+// of OuterFace. Endpoints shared by several segments
+// become a single vertex, and crossing points are rounded to the nearest integer point. An error
+// is returned if no segments are given or if a segment has coinciding endpoints.
+func BuildArrangement(lines [][4]int) (*DCEL, error) {
+	if len(lines) == 0 {
+		return nil, errors.New("no segments given")
+	}
+	d := NewDCEL()
+	vertexAt := d.vertexIndex()
+	for _, l := range lines {
+		if l[0] == l[2] && l[1] == l[3] {
+			return nil, errors.New("segment has coinciding endpoints")
+		}
+		a, b := vertexAt(l[0], l[1]), vertexAt(l[2], l[3])
+		he, twin := d.addHalfEdge(nil, b), d.addHalfEdge(nil, a)
+		he.Twin, twin.Twin = twin, he
+	}
+	if err := d.PlanarizeEdges(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
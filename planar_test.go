@@ -0,0 +1,162 @@
+package dcel
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestBuildArrangementLeavesNoCrossings(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 200; trial++ {
+		var lines [][4]int
+		for len(lines) < 15 {
+			l := [4]int{rng.Intn(30), rng.Intn(30), rng.Intn(30), rng.Intn(30)}
+			if l[0] != l[2] || l[1] != l[3] {
+				lines = append(lines, l)
+			}
+		}
+		d, err := BuildArrangement(lines)
+		if err != nil {
+			t.Fatalf("trial %d: %v", trial, err)
+		}
+		edges := d.edges()
+		for i, e := range edges {
+			for _, f := range edges[i+1:] {
+				if segmentsCross(e.Origin(), e.Target, f.Origin(), f.Target) {
+					t.Fatalf("trial %d: edges %v and %v cross", trial, e, f)
+				}
+			}
+		}
+	}
+}
+
+func TestPlanarizeEdgesPassesCheckPlanarity(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	for trial := 0; trial < 200; trial++ {
+		d := NewDCEL()
+		for len(d.HalfEdges) < 2*12 {
+			a := d.NewVertex(rng.Intn(20), rng.Intn(20))
+			b := d.NewVertex(rng.Intn(20), rng.Intn(20))
+			if a.X == b.X && a.Y == b.Y {
+				continue
+			}
+			he, tw := d.addHalfEdge(nil, b), d.addHalfEdge(nil, a)
+			he.Twin, tw.Twin = tw, he
+		}
+		if err := d.PlanarizeEdges(); err != nil {
+			t.Fatalf("trial %d: %v", trial, err)
+		}
+		if err := d.CheckPlanarity(); err != nil {
+			t.Fatalf("trial %d: %v", trial, err)
+		}
+		// Check the post-condition pairwise too, independently of the sweep
+		edges := d.edges()
+		for i, e := range edges {
+			for _, f := range edges[i+1:] {
+				a, b, c, dv := e.Origin(), e.Target, f.Origin(), f.Target
+				if segmentsCross(a, b, c, dv) || onSegment(c, a, b) || onSegment(dv, a, b) ||
+					onSegment(a, c, dv) || onSegment(b, c, dv) {
+					t.Fatalf("trial %d: edges %v and %v meet inside of them", trial, e, f)
+				}
+			}
+		}
+	}
+}
+
+func TestCheckPlanarity(t *testing.T) {
+	if err := BuildGrid(3, 3, 10, 10).CheckPlanarity(); err != nil {
+		t.Errorf("grid: %v", err)
+	}
+	tests := []struct {
+		name     string
+		segments [][4]int
+	}{
+		{"crossing", [][4]int{{0, 10, 10, 0}, {10, 10, 0, 0}}},
+		{"touching", [][4]int{{0, 0, 10, 0}, {5, 0, 5, 5}}},
+		{"overlapping", [][4]int{{0, 0, 10, 0}, {5, 0, 15, 0}}},
+	}
+	for _, tt := range tests {
+		d := NewDCEL()
+		vertexAt := d.vertexIndex()
+		for _, s := range tt.segments {
+			a, b := vertexAt(s[0], s[1]), vertexAt(s[2], s[3])
+			he, tw := d.addHalfEdge(nil, b), d.addHalfEdge(nil, a)
+			he.Twin, tw.Twin = tw, he
+		}
+		if err := d.CheckPlanarity(); err == nil {
+			t.Errorf("%s: no error", tt.name)
+		}
+	}
+}
+
+func TestBuildArrangementLongOverlappingSegments(t *testing.T) {
+	var lines [][4]int
+	// Long segments spanning the same x-range, crossing each other many times
+	for i := 0; i < 40; i++ {
+		lines = append(lines, [4]int{0, 25 * i, 1000, 1000 - 25*i})
+	}
+	// Vertical segments crossing all of them
+	for i := 1; i < 10; i++ {
+		lines = append(lines, [4]int{100 * i, -10, 100 * i, 1010})
+	}
+	// Overlapping pieces of the same horizontal and diagonal lines
+	for i := 0; i < 10; i++ {
+		lines = append(lines, [4]int{50 * i, 500, 500 + 50*i, 500}, [4]int{20 * i, 20 * i, 600 + 20*i, 600 + 20*i})
+	}
+	d, err := BuildArrangement(lines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.CheckPlanarity(); err != nil {
+		t.Fatal(err)
+	}
+	// The overlapping pieces are merged, so the horizontal line, along which one of the long
+	// segments runs too, is covered by edges once
+	length := 0
+	for _, he := range d.edges() {
+		if he.Origin().Y == 500 && he.Target.Y == 500 {
+			length += max(he.Origin().X, he.Target.X) - min(he.Origin().X, he.Target.X)
+		}
+	}
+	if length != 1000 {
+		t.Errorf("horizontal edges cover a length of %d, want 1000", length)
+	}
+	for _, l := range lines {
+		findVertex(t, d, l[0], l[1])
+		findVertex(t, d, l[2], l[3])
+	}
+}
+
+func TestBuildArrangementNestsComponents(t *testing.T) {
+	// A square with another one inside of it, and a third square beside them
+	lines := append(square(0, 0, 20, 20), square(5, 5, 15, 15)...)
+	d, err := BuildArrangement(append(lines, square(30, 0, 40, 10)...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Faces) != 4 {
+		t.Fatalf("structure has %d faces, want 4", len(d.Faces))
+	}
+	if got := len(faceAt(t, d, 1, 1).InnerComponents); got != 1 {
+		t.Errorf("face around the inner square has %d holes, want 1", got)
+	}
+	if got := len(faceAt(t, d, 10, 10).InnerComponents); got != 0 {
+		t.Errorf("inner square has %d holes, want 0", got)
+	}
+	if got := len(d.OuterFace.InnerComponents); got != 1 {
+		t.Errorf("outer face has %d inner components, want 1", got)
+	}
+	if err := d.ValidateHoles(); err != nil {
+		t.Error(err)
+	}
+	if err := d.CheckMembership(); err != nil {
+		t.Error(err)
+	}
+	// Three components share the outer face
+	if got := d.EulerCharacteristic(); got != 4 {
+		t.Errorf("EulerCharacteristic() = %d, want 4", got)
+	}
+	if got := d.SumSignedAreas(); got != 500 {
+		t.Errorf("SumSignedAreas() = %v, want 500", got)
+	}
+}
@@ -0,0 +1,188 @@
+package dcel
+
+import (
+	"math"
+	"math/big"
+)
+
+// Error bounds of the floating-point evaluation of the predicates, as derived by Shewchuk in
+// "Adaptive Precision Floating-Point Arithmetic and Fast Robust Geometric Predicates".
+var (
+	epsilon       = math.Ldexp(1, -53)
+	orientBound   = (3 + 16*epsilon) * epsilon
+	inCircleBound = (10 + 96*epsilon) * epsilon
+)
+
+// Orient2D returns a positive value if the points a, b and c are in counter-clockwise order, a
+// negative value if they are in clockwise order, and zero if they are collinear. The magnitude of
+// the result is approximately twice the area of the triangle abc. The determinant is computed in
+// floating-point arithmetic first, and evaluated again exactly if the result is too close to zero
+// for its sign to be certain, so the sign is always correct. If any coordinate is NaN or infinite,
+// there is no exact result and the floating-point result, possibly NaN, is returned as is.
+func Orient2D(ax, ay, bx, by, cx, cy float64) float64 {
+	left := (ax - cx) * (by - cy)
+	right := (ay - cy) * (bx - cx)
+	det := left - right
+	if math.Abs(det) > orientBound*(math.Abs(left)+math.Abs(right)) || !finite(ax, ay, bx, by, cx, cy) {
+		return det
+	}
+
+	r := func(x float64) *big.Rat { return new(big.Rat).SetFloat64(x) }
+	acx, bcy := new(big.Rat).Sub(r(ax), r(cx)), new(big.Rat).Sub(r(by), r(cy))
+	acy, bcx := new(big.Rat).Sub(r(ay), r(cy)), new(big.Rat).Sub(r(bx), r(cx))
+	exact := new(big.Rat).Sub(new(big.Rat).Mul(acx, bcy), new(big.Rat).Mul(acy, bcx))
+	result, _ := exact.Float64()
+	return result
+}
+
+// InCircle returns a positive value if the point d lies inside the circle through the points a, b
+// and c, a negative value if it lies outside, and zero if the four points are cocircular. The
+// points a, b and c must be in counter-clockwise order, otherwise the sign is reversed. Like
+// Orient2D, the determinant is evaluated exactly whenever the floating-point result could have the
+// wrong sign, and the floating-point result is returned as is for NaN or infinite coordinates.
+func InCircle(ax, ay, bx, by, cx, cy, dx, dy float64) float64 {
+	adx, ady := ax-dx, ay-dy
+	bdx, bdy := bx-dx, by-dy
+	cdx, cdy := cx-dx, cy-dy
+
+	bdxcdy, cdxbdy := bdx*cdy, cdx*bdy
+	cdxady, adxcdy := cdx*ady, adx*cdy
+	adxbdy, bdxady := adx*bdy, bdx*ady
+	alift := adx*adx + ady*ady
+	blift := bdx*bdx + bdy*bdy
+	clift := cdx*cdx + cdy*cdy
+
+	det := alift*(bdxcdy-cdxbdy) + blift*(cdxady-adxcdy) + clift*(adxbdy-bdxady)
+	permanent := (math.Abs(bdxcdy)+math.Abs(cdxbdy))*alift +
+		(math.Abs(cdxady)+math.Abs(adxcdy))*blift +
+		(math.Abs(adxbdy)+math.Abs(bdxady))*clift
+	if math.Abs(det) > inCircleBound*permanent || !finite(ax, ay, bx, by, cx, cy, dx, dy) {
+		return det
+	}
+
+	r := func(x, origin float64) *big.Rat {
+		return new(big.Rat).Sub(new(big.Rat).SetFloat64(x), new(big.Rat).SetFloat64(origin))
+	}
+	row := func(x, y float64) (rx, ry, lift *big.Rat) {
+		rx, ry = r(x, dx), r(y, dy)
+		lift = new(big.Rat).Add(new(big.Rat).Mul(rx, rx), new(big.Rat).Mul(ry, ry))
+		return rx, ry, lift
+	}
+	minor := func(px, py, qx, qy *big.Rat) *big.Rat {
+		return new(big.Rat).Sub(new(big.Rat).Mul(px, qy), new(big.Rat).Mul(qx, py))
+	}
+	eax, eay, eal := row(ax, ay)
+	ebx, eby, ebl := row(bx, by)
+	ecx, ecy, ecl := row(cx, cy)
+	exact := new(big.Rat).Mul(eal, minor(ebx, eby, ecx, ecy))
+	exact.Add(exact, new(big.Rat).Mul(ebl, minor(ecx, ecy, eax, eay)))
+	exact.Add(exact, new(big.Rat).Mul(ecl, minor(eax, eay, ebx, eby)))
+	result, _ := exact.Float64()
+	return result
+}
+
+// finite returns true if none of the values is NaN or infinite, so they can be converted to exact
+// rational numbers.
+func finite(values ...float64) bool {
+	for _, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// orient returns twice the signed area of the triangle formed by the three vertices. The result
+// is positive if a, b and c are in counter-clockwise order, negative if in clockwise order and
+// zero if the vertices are collinear.
+func orient(a, b, c *Vertex) int64 {
+	return int64(b.X-a.X)*int64(c.Y-a.Y) - int64(b.Y-a.Y)*int64(c.X-a.X)
+}
+
+// inCircle returns 1 if vertex d lies inside the circumcircle of the counter-clockwise triangle
+// abc, -1 if it lies outside and 0 if the four vertices are cocircular. The determinant is
+// evaluated with arbitrary precision, so the result is exact for any integer coordinates.
+func inCircle(a, b, c, d *Vertex) int {
+	row := func(v *Vertex) (x, y, lift *big.Int) {
+		x = big.NewInt(int64(v.X - d.X))
+		y = big.NewInt(int64(v.Y - d.Y))
+		lift = new(big.Int).Add(new(big.Int).Mul(x, x), new(big.Int).Mul(y, y))
+		return x, y, lift
+	}
+	ax, ay, al := row(a)
+	bx, by, bl := row(b)
+	cx, cy, cl := row(c)
+
+	minor := func(px, py, qx, qy *big.Int) *big.Int {
+		return new(big.Int).Sub(new(big.Int).Mul(px, qy), new(big.Int).Mul(qx, py))
+	}
+	det := new(big.Int).Mul(al, minor(bx, by, cx, cy))
+	det.Add(det, new(big.Int).Mul(bl, minor(cx, cy, ax, ay)))
+	det.Add(det, new(big.Int).Mul(cl, minor(ax, ay, bx, by)))
+	return det.Sign()
+}
+
+// segmentsCross returns true if the segments ab and cd intersect at a single point that is
+// interior to both of them.
+func segmentsCross(a, b, c, d *Vertex) bool {
+	return sign(orient(a, b, c))*sign(orient(a, b, d)) < 0 &&
+		sign(orient(c, d, a))*sign(orient(c, d, b)) < 0
+}
+
+// segmentsIntersect returns true if the closed segments ab and cd have at least one point in
+// common, including their endpoints.
+func segmentsIntersect(a, b, c, d *Vertex) bool {
+	same := func(v, w *Vertex) bool { return v.X == w.X && v.Y == w.Y }
+	return segmentsCross(a, b, c, d) || onSegment(c, a, b) || onSegment(d, a, b) ||
+		onSegment(a, c, d) || onSegment(b, c, d) || same(a, c) || same(a, d) || same(b, c) || same(b, d)
+}
+
+// onSegment returns true if vertex v lies on the segment ab, excluding its endpoints.
+func onSegment(v, a, b *Vertex) bool {
+	if orient(a, b, v) != 0 || (v.X == a.X && v.Y == a.Y) || (v.X == b.X && v.Y == b.Y) {
+		return false
+	}
+	return min(a.X, b.X) <= v.X && v.X <= max(a.X, b.X) && min(a.Y, b.Y) <= v.Y && v.Y <= max(a.Y, b.Y)
+}
+
+func sign(x int64) int {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	}
+	return 0
+}
+
+// distance returns the euclidean distance between two vertices.
+func distance(a, b *Vertex) float64 {
+	return math.Hypot(float64(b.X-a.X), float64(b.Y-a.Y))
+}
+
+// distanceToLine returns the distance from vertex v to the line through a and b, or to a if the
+// two points coincide.
+func distanceToLine(v, a, b *Vertex) float64 {
+	length := distance(a, b)
+	if length == 0 {
+		return distance(v, a)
+	}
+	return math.Abs(float64(orient(a, b, v))) / length
+}
+
+// distanceToSegment returns the distance from vertex v to the closest point of the segment ab.
+func distanceToSegment(v, a, b *Vertex) float64 {
+	x, y := closestOnSegment(float64(v.X), float64(v.Y), a, b)
+	return math.Hypot(float64(v.X)-x, float64(v.Y)-y)
+}
+
+// closestOnSegment returns the point of the segment ab that is closest to the point (x, y).
+func closestOnSegment(x, y float64, a, b *Vertex) (float64, float64) {
+	dx, dy := float64(b.X-a.X), float64(b.Y-a.Y)
+	if dx == 0 && dy == 0 {
+		return float64(a.X), float64(a.Y)
+	}
+	t := ((x-float64(a.X))*dx + (y-float64(a.Y))*dy) / (dx*dx + dy*dy)
+	t = math.Max(0, math.Min(1, t))
+	return float64(a.X) + t*dx, float64(a.Y) + t*dy
+}
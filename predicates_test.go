@@ -0,0 +1,174 @@
+package dcel
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+// exactOrient returns the sign of the orientation determinant of a, b and c, computed exactly.
+func exactOrient(ax, ay, bx, by, cx, cy float64) int {
+	r := func(x float64) *big.Rat { return new(big.Rat).SetFloat64(x) }
+	left := new(big.Rat).Mul(new(big.Rat).Sub(r(ax), r(cx)), new(big.Rat).Sub(r(by), r(cy)))
+	right := new(big.Rat).Mul(new(big.Rat).Sub(r(ay), r(cy)), new(big.Rat).Sub(r(bx), r(cx)))
+	return left.Cmp(right)
+}
+
+// exactInCircle returns the sign of the in-circle determinant of a, b, c and d, computed exactly
+// by expanding the 4x4 determinant with rows (x, y, x²+y², 1).
+func exactInCircle(ax, ay, bx, by, cx, cy, dx, dy float64) int {
+	r := func(x float64) *big.Rat { return new(big.Rat).SetFloat64(x) }
+	rows := [4][3]*big.Rat{}
+	for i, p := range [4][2]float64{{ax, ay}, {bx, by}, {cx, cy}, {dx, dy}} {
+		x, y := r(p[0]), r(p[1])
+		rows[i] = [3]*big.Rat{x, y, new(big.Rat).Add(new(big.Rat).Mul(x, x), new(big.Rat).Mul(y, y))}
+	}
+	det3 := func(m [3][3]*big.Rat) *big.Rat {
+		cof := func(a, b, c, d *big.Rat) *big.Rat {
+			return new(big.Rat).Sub(new(big.Rat).Mul(a, d), new(big.Rat).Mul(b, c))
+		}
+		det := new(big.Rat).Mul(m[0][0], cof(m[1][1], m[1][2], m[2][1], m[2][2]))
+		det.Sub(det, new(big.Rat).Mul(m[0][1], cof(m[1][0], m[1][2], m[2][0], m[2][2])))
+		return det.Add(det, new(big.Rat).Mul(m[0][2], cof(m[1][0], m[1][1], m[2][0], m[2][1])))
+	}
+	// Expand along the column of ones, whose cofactor signs alternate starting with minus
+	det := new(big.Rat)
+	for skip := 0; skip < 4; skip++ {
+		var m [3][3]*big.Rat
+		k := 0
+		for i := 0; i < 4; i++ {
+			if i != skip {
+				m[k] = rows[i]
+				k++
+			}
+		}
+		if skip%2 == 0 {
+			det.Sub(det, det3(m))
+		} else {
+			det.Add(det, det3(m))
+		}
+	}
+	return det.Sign()
+}
+
+func signOf(x float64) int {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	}
+	return 0
+}
+
+func TestOrient2DExact(t *testing.T) {
+	tests := []struct {
+		name                   string
+		ax, ay, bx, by, cx, cy float64
+		want                   int
+	}{
+		{"counter-clockwise", 0, 0, 1, 0, 0, 1, 1},
+		{"clockwise", 0, 0, 0, 1, 1, 0, -1},
+		{"collinear", 0, 0, 1, 1, 2, 2, 0},
+		{"collinear fractions", 0.1, 0.1, 0.2, 0.2, 0.3, 0.3, exactOrient(0.1, 0.1, 0.2, 0.2, 0.3, 0.3)},
+		{"collinear large", 1e15, 1e15, 2e15, 2e15, 3e15, 3e15, 0},
+		{"coincident", 1, 2, 1, 2, 3, 4, 0},
+	}
+	for _, tt := range tests {
+		if got := signOf(Orient2D(tt.ax, tt.ay, tt.bx, tt.by, tt.cx, tt.cy)); got != tt.want {
+			t.Errorf("%s: Orient2D sign = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestOrient2DNearDegenerate evaluates the orientation of points on a 256x256 grid of the smallest
+// steps around (0.5, 0.5) against the line through (12, 12) and (24, 24), as in Shewchuk's paper.
+// Most of them are too close to the line for the floating-point determinant to have the right
+// sign, so they take the exact fallback.
+func TestOrient2DNearDegenerate(t *testing.T) {
+	step := math.Ldexp(1, -53)
+	wrong := 0
+	for i := 0; i < 256; i++ {
+		for j := 0; j < 256; j++ {
+			ax, ay := 0.5+float64(i)*step, 0.5+float64(j)*step
+			want := exactOrient(ax, ay, 12, 12, 24, 24)
+			if got := signOf(Orient2D(ax, ay, 12, 12, 24, 24)); got != want {
+				t.Fatalf("Orient2D(%v, %v, 12, 12, 24, 24) sign = %d, want %d", ax, ay, got, want)
+			}
+			naive := (ax-24)*(12-24) - (ay-24)*(12-24)
+			if signOf(naive) != want {
+				wrong++
+			}
+		}
+	}
+	if wrong == 0 {
+		t.Error("no grid point needed the exact evaluation")
+	}
+}
+
+func TestInCircleExact(t *testing.T) {
+	tests := []struct {
+		name                           string
+		ax, ay, bx, by, cx, cy, dx, dy float64
+		want                           int
+	}{
+		{"inside", 0, 0, 2, 0, 0, 2, 1, 1, 1},
+		{"outside", 0, 0, 2, 0, 0, 2, 3, 3, -1},
+		{"cocircular", 1, 0, 0, 1, -1, 0, 0, -1, 0},
+		{"cocircular square", 0, 0, 4, 0, 4, 4, 0, 4, 0},
+		{"cocircular large", 5e7, 0, 0, 5e7, -5e7, 0, 3e7, -4e7, 0},
+	}
+	for _, tt := range tests {
+		if got := signOf(InCircle(tt.ax, tt.ay, tt.bx, tt.by, tt.cx, tt.cy, tt.dx, tt.dy)); got != tt.want {
+			t.Errorf("%s: InCircle sign = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestInCircleNearDegenerate moves the fourth point of a cocircular set by the smallest steps
+// around its position on the unit circle, where the floating-point determinant is dominated by
+// rounding errors.
+func TestInCircleNearDegenerate(t *testing.T) {
+	step := math.Ldexp(1, -53)
+	for i := -64; i <= 64; i++ {
+		for j := -64; j <= 64; j++ {
+			dx, dy := float64(i)*step, -1+float64(j)*step
+			want := exactInCircle(1, 0, 0, 1, -1, 0, dx, dy)
+			if got := signOf(InCircle(1, 0, 0, 1, -1, 0, dx, dy)); got != want {
+				t.Fatalf("InCircle(1, 0, 0, 1, -1, 0, %v, %v) sign = %d, want %d", dx, dy, got, want)
+			}
+		}
+	}
+}
+
+func TestInCircleClockwiseReversesSign(t *testing.T) {
+	for _, p := range [][2]float64{{1, 1}, {3, 3}, {0.5, 0.25}, {-1, 5}} {
+		ccw := InCircle(0, 0, 2, 0, 0, 2, p[0], p[1])
+		cw := InCircle(0, 0, 0, 2, 2, 0, p[0], p[1])
+		if signOf(ccw) == 0 || signOf(cw) != -signOf(ccw) {
+			t.Errorf("point %v: InCircle sign is %d for the CCW triangle and %d for the CW one",
+				p, signOf(ccw), signOf(cw))
+		}
+	}
+}
+
+func TestPredicatesNonFinite(t *testing.T) {
+	inf, nan := math.Inf(1), math.NaN()
+	// None of these may panic in the exact fallback
+	for _, p := range [][6]float64{
+		{inf, 0, 1, 1, 2, 0},
+		{0, 0, -inf, 1, 2, 0},
+		{nan, 0, 1, 1, 2, 0},
+		{0, 0, 0, 0, 0, nan},
+	} {
+		Orient2D(p[0], p[1], p[2], p[3], p[4], p[5])
+		InCircle(p[0], p[1], p[2], p[3], p[4], p[5], 1, 1)
+		InCircle(0, 0, 1, 0, 0, 1, p[0], p[1])
+	}
+	if got := Orient2D(nan, 0, 1, 1, 2, 0); !math.IsNaN(got) {
+		t.Errorf("Orient2D with a NaN coordinate = %v, want NaN", got)
+	}
+	if got := InCircle(0, 0, 1, 0, 0, 1, inf, inf); !math.IsNaN(got) && !math.IsInf(got, 0) {
+		t.Errorf("InCircle with an infinite point = %v, want NaN or an infinity", got)
+	}
+}
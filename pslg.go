@@ -0,0 +1,77 @@
+package dcel
+
+import "errors"
+
+// BuildFromPSLGStream traces the bounded faces of the planar straight-line graph given by points
+// and segments, each segment being a pair of indices into points, and calls onFace with the
+// vertices of each face as soon as its boundary has been traced, instead of building a DCEL. The
+// vertices are listed counter-clockwise. Only the vertices and half-edges of the graph are kept
+// in memory while tracing, so no face outlives the call to onFace for it.
+//
+// The faces are traced like RebuildFaces does, by sorting the edges around each vertex by angle,
+// so the segments must not cross each other; use BuildArrangement to split crossing segments
+// first. Unlike RebuildFaces, the tracing does not link holes to the faces around them: each face
+// is reported by its outer boundary alone, and the boundaries enclosing the connected groups of
+// segments are not reported. Points
+// not used by any segment are ignored, duplicate points are merged into a single vertex and
+// duplicate segments into a single edge. An error is returned if no segments are given, if a
+// segment refers to a point that does not exist, or if its endpoints coincide.
+func BuildFromPSLGStream(points [][2]int, segments [][2]int, onFace func(verts [][2]int)) error {
+	if len(segments) == 0 {
+		return errors.New("no segments given")
+	}
+	var vertices []*Vertex
+	byCoords := make(map[[2]int]*Vertex)
+	vertexAt := func(p [2]int) *Vertex {
+		if v, ok := byCoords[p]; ok {
+			return v
+		}
+		v := &Vertex{X: p[0], Y: p[1]}
+		vertices = append(vertices, v)
+		byCoords[p] = v
+		return v
+	}
+
+	var halfEdges []*HalfEdge
+	outgoing := make(map[*Vertex][]*HalfEdge)
+	seen := make(map[[2]*Vertex]bool)
+	for _, s := range segments {
+		if s[0] < 0 || s[0] >= len(points) || s[1] < 0 || s[1] >= len(points) {
+			return errors.New("segment refers to a point that does not exist")
+		}
+		a, b := vertexAt(points[s[0]]), vertexAt(points[s[1]])
+		if a == b {
+			return errors.New("segment has coinciding endpoints")
+		}
+		if seen[[2]*Vertex{a, b}] {
+			continue
+		}
+		seen[[2]*Vertex{a, b}], seen[[2]*Vertex{b, a}] = true, true
+		he, tw := &HalfEdge{Target: b}, &HalfEdge{Target: a}
+		he.Twin, tw.Twin = tw, he
+		halfEdges = append(halfEdges, he, tw)
+		outgoing[a] = append(outgoing[a], he)
+		outgoing[b] = append(outgoing[b], tw)
+	}
+	linkByAngle(vertices, outgoing)
+
+	visited := make(map[*HalfEdge]bool, len(halfEdges))
+	for _, start := range halfEdges {
+		if visited[start] {
+			continue
+		}
+		edges := cycle(start)
+		for _, he := range edges {
+			visited[he] = true
+		}
+		if ringArea(edges) <= 0 {
+			continue
+		}
+		verts := make([][2]int, len(edges))
+		for i, he := range edges {
+			verts[i] = [2]int{he.Target.X, he.Target.Y}
+		}
+		onFace(verts)
+	}
+	return nil
+}
@@ -0,0 +1,317 @@
+package dcel
+
+import (
+	"math"
+	"sort"
+)
+
+// FacesByArea returns the bounded faces of the structure sorted by their absolute area, in
+// ascending order or in descending order if descending is true. Faces with equal area keep the
+// order in which they are stored. The outer face is excluded.
+func (d *DCEL) FacesByArea(descending bool) []*Face {
+	faces := d.boundedFaces()
+	areas := make(map[*Face]float64, len(faces))
+	for _, face := range faces {
+		areas[face] = math.Abs(face.Area())
+	}
+	sort.SliceStable(faces, func(i, j int) bool {
+		if descending {
+			return areas[faces[i]] > areas[faces[j]]
+		}
+		return areas[faces[i]] < areas[faces[j]]
+	})
+	return faces
+}
+
+// SmallestFace returns the bounded face with the smallest absolute area, the first one stored if
+// several have that area, or nil if the structure has no bounded faces.
+func (d *DCEL) SmallestFace() *Face {
+	faces := d.FacesByArea(false)
+	if len(faces) == 0 {
+		return nil
+	}
+	return faces[0]
+}
+
+// Orientation returns 1 if the bounded faces of the structure are oriented counter-clockwise and
+// -1 if they are oriented clockwise. The orientation is derived from the signed area of the outer
+// face, whose boundary runs opposite to the faces inside it, or from the bounded face with the
+// largest absolute area if OuterFace is not set. Zero is returned if the orientation can not be
+// determined, e.g. because the structure has no faces with a non-zero area.
+func (d *DCEL) Orientation() int {
+	var area float64
+	if d.OuterFace != nil {
+		area = -d.OuterFace.Area()
+	}
+	if area == 0 {
+		for _, face := range d.boundedFaces() {
+			if a := face.Area(); math.Abs(a) > math.Abs(area) {
+				area = a
+			}
+		}
+	}
+	switch {
+	case area > 0:
+		return 1
+	case area < 0:
+		return -1
+	}
+	return 0
+}
+
+// InteriorEdges returns one half-edge of each edge pair that does not lie on the outer boundary,
+// i.e. of each edge with a twin where neither side is the outer face. In a triangulated polygon
+// these are its diagonals.
+func (d *DCEL) InteriorEdges() []*HalfEdge {
+	var edges []*HalfEdge
+	for _, he := range d.edges() {
+		if he.Twin != nil && !he.IsBoundary() {
+			edges = append(edges, he)
+		}
+	}
+	return edges
+}
+
+// FacesBySweepOrder returns the bounded faces of the structure in the order a sweep line moving
+// from left to right reaches them, i.e. sorted by the smallest x coordinate of their boundary
+// vertices, and by the smallest y coordinate among the vertices with that x in case of a tie.
+// Faces without boundary vertices come last. The outer face is excluded.
+func (d *DCEL) FacesBySweepOrder() []*Face {
+	faces := d.boundedFaces()
+	leftmost := make(map[*Face]*Vertex, len(faces))
+	for _, face := range faces {
+		var lowest *Vertex
+		for _, v := range face.Vertices() {
+			if v != nil && (lowest == nil || v.X < lowest.X || v.X == lowest.X && v.Y < lowest.Y) {
+				lowest = v
+			}
+		}
+		leftmost[face] = lowest
+	}
+	sort.SliceStable(faces, func(i, j int) bool {
+		a, b := leftmost[faces[i]], leftmost[faces[j]]
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		return a.X < b.X || a.X == b.X && a.Y < b.Y
+	})
+	return faces
+}
+
+// ParentFace returns the face that contains f in one of its holes, or nil if f is not nested in
+// another face. A face g is the parent of f if one of the inner components of g runs along the
+// boundary of f. Otherwise, the parent is the bounded face with the smallest area that contains
+// all boundary vertices of f, with at least one of them strictly inside, as the holes of the
+// structure may not be linked to inner components, e.g. in structures built by hand.
+func (d *DCEL) ParentFace(f *Face) *Face {
+	for _, g := range d.Faces {
+		for _, hole := range g.InnerComponents {
+			for _, he := range cycle(hole) {
+				if he.Twin != nil && he.Twin.Face == f {
+					return g
+				}
+			}
+		}
+	}
+
+	var parent *Face
+	vertices := f.Vertices()
+	for _, g := range d.boundedFaces() {
+		if g == f || parent != nil && math.Abs(g.Area()) >= math.Abs(parent.Area()) {
+			continue
+		}
+		inside := false
+		for _, v := range vertices {
+			if v == nil {
+				continue
+			}
+			class := g.Classify(v.X, v.Y)
+			if class < 0 {
+				inside = false
+				break
+			}
+			inside = inside || class > 0
+		}
+		if inside {
+			parent = g
+		}
+	}
+	return parent
+}
+
+// HalfEdgeByTarget returns all half-edges whose target vertex lies at (x, y), in the order they are
+// stored. Several half-edges usually match, one for every edge incident to the vertex, and more
+// if several vertices share the coordinates.
+func (d *DCEL) HalfEdgeByTarget(x, y int) []*HalfEdge {
+	var edges []*HalfEdge
+	for _, he := range d.HalfEdges {
+		if he.Target != nil && he.Target.X == x && he.Target.Y == y {
+			edges = append(edges, he)
+		}
+	}
+	return edges
+}
+
+// LabelBoundary returns one half-edge of each edge that separates two faces with different labels,
+// as returned by the label function, in the order they are stored. The label function is called
+// for the outer face too, so it decides whether the outer boundary counts as dividing edges. Edges
+// without a twin or with a missing face on either side are skipped.
+func (d *DCEL) LabelBoundary(label func(f *Face) string) []*HalfEdge {
+	labels := make(map[*Face]string)
+	labelOf := func(f *Face) string {
+		l, ok := labels[f]
+		if !ok {
+			l = label(f)
+			labels[f] = l
+		}
+		return l
+	}
+	var edges []*HalfEdge
+	for _, he := range d.edges() {
+		if he.Twin == nil || he.Face == nil || he.Twin.Face == nil {
+			continue
+		}
+		if labelOf(he.Face) != labelOf(he.Twin.Face) {
+			edges = append(edges, he)
+		}
+	}
+	return edges
+}
+
+// FacesInRect returns the bounded faces that have at least one point in common with the rectangle
+// with the given corners, including the faces that only touch it, in the order they are stored.
+// Faces whose bounding box does not overlap the rectangle are skipped early, and the others are
+// tested precisely: a face overlaps the rectangle if one of its boundary vertices lies in the
+// rectangle, if a corner of the rectangle lies in the face, or if their boundaries cross. The
+// holes of the faces are not taken into account. Faces without a closed boundary are skipped.
+func (d *DCEL) FacesInRect(minX, minY, maxX, maxY int) []*Face {
+	corners := []*Vertex{{X: minX, Y: minY}, {X: maxX, Y: minY}, {X: maxX, Y: maxY}, {X: minX, Y: maxY}}
+	var faces []*Face
+	for _, f := range d.boundedFaces() {
+		edges := f.boundary()
+		if len(edges) == 0 {
+			continue
+		}
+		fMinX, fMinY, fMaxX, fMaxY := f.BoundingBox()
+		if fMaxX < minX || fMinX > maxX || fMaxY < minY || fMinY > maxY {
+			continue
+		}
+		if rectOverlapsRing(edges, corners) {
+			faces = append(faces, f)
+		}
+	}
+	return faces
+}
+
+// rectOverlapsRing returns true if the closed cycle of half-edges and the axis-aligned rectangle
+// with the given corners, listed in order, have at least one point in common.
+func rectOverlapsRing(edges []*HalfEdge, corners []*Vertex) bool {
+	minX, minY, maxX, maxY := corners[0].X, corners[0].Y, corners[2].X, corners[2].Y
+	for _, he := range edges {
+		if v := he.Target; minX <= v.X && v.X <= maxX && minY <= v.Y && v.Y <= maxY {
+			return true
+		}
+	}
+	for _, c := range corners {
+		if classifyRing(edges, c) >= 0 {
+			return true
+		}
+	}
+	for i, he := range edges {
+		a := edges[(i+len(edges)-1)%len(edges)].Target
+		for j, c := range corners {
+			if segmentsIntersect(a, he.Target, c, corners[(j+1)%len(corners)]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// OrientationReport splits the bounded faces of the structure by the orientation of their
+// boundary, as given by the sign of their area: ccw holds the faces oriented counter-clockwise and
+// cw the ones oriented clockwise, both in the order they are stored. Faces with zero area, such as
+// degenerate or open ones, are in neither list. In a consistently built structure one of the lists
+// is empty, while the outer face, which is not included, runs the other way.
+func (d *DCEL) OrientationReport() (ccw, cw []*Face) {
+	for _, face := range d.boundedFaces() {
+		switch area := face.Area(); {
+		case area > 0:
+			ccw = append(ccw, face)
+		case area < 0:
+			cw = append(cw, face)
+		}
+	}
+	return ccw, cw
+}
+
+// IsSinglePolygon returns true if the structure represents a single simple polygon: it has exactly
+// two faces, a bounded one with a simple boundary and the OuterFace, neither of them has holes, and
+// every half-edge is on the boundary of the polygon, with its twin on the boundary of the outer
+// face.
+func (d *DCEL) IsSinglePolygon() bool {
+	if d.OuterFace == nil || len(d.Faces) != 2 {
+		return false
+	}
+	faces := d.boundedFaces()
+	if len(faces) != 1 {
+		return false
+	}
+	polygon := faces[0]
+	if len(polygon.InnerComponents) > 0 || len(d.OuterFace.InnerComponents) > 0 || !polygon.IsSimple() {
+		return false
+	}
+	edges := polygon.boundary()
+	if len(d.HalfEdges) != 2*len(edges) {
+		return false
+	}
+	for _, he := range edges {
+		if he.Twin == nil || he.Twin.Twin != he || he.Twin.Face != d.OuterFace {
+			return false
+		}
+	}
+	return len(d.OuterFace.boundary()) == len(edges)
+}
+
+// RayCast returns the edge that the ray starting at (ox, oy) in the direction (dx, dy) hits first,
+// along with the distance from the origin to the hit point. Of the two half-edges of the edge, the
+// one facing the origin is returned, i.e. the one whose face the ray travels through before the
+// hit, unless the edge has no twin. Edges that contain the origin are ignored, as are edges that
+// the ray runs along, which are hit at their endpoints instead. If several edges are hit at the
+// same distance, such as at a shared vertex, the one stored first wins. The edges are scanned one
+// by one, so the query takes linear time. If the ray hits nothing, or the direction is zero, nil
+// and zero are returned.
+func (d *DCEL) RayCast(ox, oy int, dx, dy float64) (*HalfEdge, float64) {
+	if dx == 0 && dy == 0 {
+		return nil, 0
+	}
+	p := &Vertex{X: ox, Y: oy}
+	var segments [][2]*Vertex
+	var edges []*HalfEdge
+	for _, he := range d.edges() {
+		if a := he.Origin(); a != nil && he.Target != nil {
+			segments = append(segments, [2]*Vertex{a, he.Target})
+			edges = append(edges, he)
+		}
+	}
+
+	var hit *HalfEdge
+	nearest := math.Inf(1)
+	for i, s := range segments {
+		// castRay checks the single segment, skipping it if it contains the origin
+		x, y, ok := castRay(p, dx, dy, segments[i:i+1])
+		if !ok {
+			continue
+		}
+		if dist := math.Hypot(x-float64(ox), y-float64(oy)); dist < nearest {
+			hit, nearest = edges[i], dist
+			if orient(s[0], s[1], p) < 0 && hit.Twin != nil {
+				hit = hit.Twin
+			}
+		}
+	}
+	if hit == nil {
+		return nil, 0
+	}
+	return hit, nearest
+}
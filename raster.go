@@ -0,0 +1,148 @@
+package dcel
+
+import "errors"
+
+// FromRaster traces the boundaries of the true regions of a boolean grid and builds a structure
+// with one bounded face per region. The grid is indexed as grid[row][col], and the cell at row r
+// and column c is the unit square from (c, r) to (c+1, r+1), so the first row lies at the bottom.
+//
+// The boundaries are traced as in marching squares: every corner of the lattice is classified by
+// the four cells around it, and an edge runs between each pair of adjacent cells that differ.
+// Collinear runs of such edges are merged, so only the corners of the regions become vertices.
+// Cells belong to the same region only if they share a side. Where two true cells touch only at a
+// corner, the ambiguous saddle case of marching squares, they are kept apart: the boundaries meet
+// at the shared vertex, but each turns around its own cell. The same rule makes two false cells
+// touching at a corner separate holes.
+//
+// Each region is a counter-clockwise bounded face, stored in the order in which their lowest,
+// left-most cells appear, and the boundaries of the holes inside a region are its
+// InnerComponents. The rest of the plane, including the holes themselves, is the OuterFace, whose
+// HalfEdge is on the boundary enclosing the largest area, while each other boundary of the region
+// is one of its InnerComponents. An error is returned if the rows of the grid do not all have the
+// same length, or if no cell is true.
+func FromRaster(grid [][]bool) (*DCEL, error) {
+	cols := 0
+	if len(grid) > 0 {
+		cols = len(grid[0])
+	}
+	for _, row := range grid {
+		if len(row) != cols {
+			return nil, errors.New("rows of the grid have different lengths")
+		}
+	}
+	filled := func(r, c int) bool {
+		return r >= 0 && r < len(grid) && c >= 0 && c < cols && grid[r][c]
+	}
+
+	d := NewDCEL()
+	d.OuterFace = d.NewFace()
+
+	// Label the regions, cells that share a side being in the same region
+	region := make([][]*Face, len(grid))
+	for r := range grid {
+		region[r] = make([]*Face, cols)
+	}
+	for r := range grid {
+		for c := range grid[r] {
+			if !grid[r][c] || region[r][c] != nil {
+				continue
+			}
+			face := d.NewFace()
+			region[r][c] = face
+			stack := [][2]int{{r, c}}
+			for len(stack) > 0 {
+				cell := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				for _, step := range [][2]int{{0, 1}, {1, 0}, {0, -1}, {-1, 0}} {
+					nr, nc := cell[0]+step[0], cell[1]+step[1]
+					if filled(nr, nc) && region[nr][nc] == nil {
+						region[nr][nc] = face
+						stack = append(stack, [2]int{nr, nc})
+					}
+				}
+			}
+		}
+	}
+	if len(d.Faces) == 1 {
+		return nil, errors.New("grid has no true cells")
+	}
+
+	vertex := d.vertexIndex()
+	// addSide adds the side of a true cell from (x1, y1) to (x2, y2), running counter-clockwise
+	// around the cell, and its twin in the OuterFace
+	addSide := func(face *Face, x1, y1, x2, y2 int) {
+		he := d.addHalfEdge(face, vertex(x2, y2))
+		he.Twin = d.addHalfEdge(d.OuterFace, vertex(x1, y1))
+		he.Twin.Twin = he
+	}
+	for r := range grid {
+		for c := range grid[r] {
+			face := region[r][c]
+			if face == nil {
+				continue
+			}
+			if !filled(r-1, c) {
+				addSide(face, c, r, c+1, r)
+			}
+			if !filled(r, c+1) {
+				addSide(face, c+1, r, c+1, r+1)
+			}
+			if !filled(r+1, c) {
+				addSide(face, c+1, r+1, c, r+1)
+			}
+			if !filled(r, c-1) {
+				addSide(face, c, r+1, c, r)
+			}
+		}
+	}
+
+	// RebuildFaces links the sides around each corner, turning around the cell of each boundary at
+	// the saddle corners, and makes a face or an inner component of every boundary. Regroup the
+	// boundaries by the face they were traced for, keeping the one enclosing the largest area as
+	// the outer boundary.
+	source := make(map[*HalfEdge]*Face, len(d.HalfEdges))
+	for _, he := range d.HalfEdges {
+		source[he] = he.Face
+	}
+	faces := d.Faces
+	if err := d.RebuildFaces(); err != nil {
+		return nil, err
+	}
+	boundaries := make(map[*Face][]*HalfEdge)
+	for _, f := range d.Faces {
+		for _, start := range append([]*HalfEdge{f.HalfEdge}, f.InnerComponents...) {
+			owner := source[start]
+			boundaries[owner] = append(boundaries[owner], start)
+		}
+	}
+	for _, face := range faces {
+		starts := boundaries[face]
+		sign := 1.0
+		if face == faces[0] {
+			sign = -1
+		}
+		best := 0
+		for i, start := range starts {
+			if sign*ringArea(cycle(start)) > sign*ringArea(cycle(starts[best])) {
+				best = i
+			}
+		}
+		face.HalfEdge = starts[best]
+		face.InnerComponents = nil
+		for i, start := range starts {
+			for _, he := range cycle(start) {
+				he.Face = face
+			}
+			if i != best {
+				face.InnerComponents = append(face.InnerComponents, start)
+			}
+		}
+	}
+	d.Faces = faces
+	d.OuterFace = faces[0]
+
+	d.dissolveVertices(func(v, a, b *Vertex) bool {
+		return orient(a, b, v) == 0
+	})
+	return d, nil
+}
@@ -0,0 +1,64 @@
+package dcel
+
+import "testing"
+
+func TestFromRaster(t *testing.T) {
+	// The holes are separate components of the boundary, but not faces of their own, so the Euler
+	// characteristic is 1 plus the number of components of the boundary minus the number of holes
+	tests := []struct {
+		name           string
+		grid           [][]bool
+		regions        int
+		inner          int
+		vertices       int
+		characteristic int
+	}{
+		{"single cell", [][]bool{{true}}, 1, 0, 4, 2},
+		{"L shape", [][]bool{{true, true}, {true, false}}, 1, 0, 6, 2},
+		{"ring", [][]bool{{true, true, true}, {true, false, true}, {true, true, true}}, 1, 1, 8, 2},
+		{"separate cells", [][]bool{{true, false, true}}, 2, 0, 8, 3},
+		{"saddle", [][]bool{{true, false}, {false, true}}, 2, 0, 7, 2},
+		// The two false cells are separate holes, but the boundary of the region around them runs
+		// through their common corner twice, so the region has a single inner component
+		{"saddle hole", [][]bool{
+			{true, true, true, true},
+			{true, false, true, true},
+			{true, true, false, true},
+			{true, true, true, true},
+		}, 1, 1, 11, 1},
+	}
+	for _, tt := range tests {
+		d, err := FromRaster(tt.grid)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got := len(d.boundedFaces()); got != tt.regions {
+			t.Errorf("%s: structure has %d regions, want %d", tt.name, got, tt.regions)
+		}
+		inner := 0
+		for _, f := range d.boundedFaces() {
+			inner += f.HoleCount()
+		}
+		if inner != tt.inner {
+			t.Errorf("%s: regions have %d inner components, want %d", tt.name, inner, tt.inner)
+		}
+		if got := len(d.Vertices); got != tt.vertices {
+			t.Errorf("%s: structure has %d vertices, want %d", tt.name, got, tt.vertices)
+		}
+		if got := d.EulerCharacteristic(); got != tt.characteristic {
+			t.Errorf("%s: EulerCharacteristic() = %d, want %d", tt.name, got, tt.characteristic)
+		}
+		assertLinked(t, d)
+		if err := d.ValidateHoles(); err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		}
+	}
+}
+
+func TestFromRasterErrors(t *testing.T) {
+	for _, grid := range [][][]bool{nil, {{false, false}}, {{true, true}, {true}}} {
+		if _, err := FromRaster(grid); err == nil {
+			t.Errorf("FromRaster(%v) returned no error", grid)
+		}
+	}
+}
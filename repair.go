@@ -0,0 +1,92 @@
+package dcel
+
+import "fmt"
+
+// RepairVertexPointers makes sure that the HalfEdge pointer of every vertex refers to a half-edge
+// that targets the vertex. Vertices pointing to a half-edge with a different target are re-pointed
+// to any half-edge of the structure that targets them, or to nil if there is none. It returns the
+// number of vertices whose pointer was changed.
+func (d *DCEL) RepairVertexPointers() int {
+	defer d.beginOperation(true)()
+	incoming := make(map[*Vertex]*HalfEdge)
+	for _, he := range d.HalfEdges {
+		if he.Target != nil && incoming[he.Target] == nil {
+			incoming[he.Target] = he
+		}
+	}
+
+	fixed := 0
+	for _, v := range d.Vertices {
+		if v.HalfEdge != nil && v.HalfEdge.Target == v || v.HalfEdge == nil && incoming[v] == nil {
+			continue
+		}
+		v.HalfEdge = incoming[v]
+		fixed++
+	}
+	return fixed
+}
+
+// RepairNextPrev makes the Prev pointers of the structure consistent with its Next pointers, by
+// setting he.Next.Prev to he for every half-edge he with a Next pointer. This recovers structures
+// in which only the Next pointers were set while building them. Prev pointers of half-edges that
+// no half-edge continues with are left untouched, and if several half-edges continue with the
+// same one, the last of them in storage order wins. It returns the number of Prev pointers that
+// were changed.
+func (d *DCEL) RepairNextPrev() int {
+	defer d.beginOperation(true)()
+	fixed := 0
+	for _, he := range d.HalfEdges {
+		if he.Next != nil && he.Next.Prev != he {
+			he.Next.Prev = he
+			fixed++
+		}
+	}
+	return fixed
+}
+
+// RebuildTwins pairs every half-edge with a half-edge running in the opposite direction between
+// the same two vertices, and sets their Twin pointers to each other. The origins of the
+// half-edges are taken from the targets of their Prev half-edges, so the faces must be linked
+// already. If several edges connect the same vertices, half-edges are paired in the order they
+// are stored. An error is returned, and no pointer is changed, if a half-edge has no origin or
+// target, or if no opposite half-edge is left to pair it with. Open boundaries therefore need
+// explicit half-edges in the outer face.
+func (d *DCEL) RebuildTwins() error {
+	type key struct{ origin, target *Vertex }
+	byEnds := make(map[key][]*HalfEdge)
+	for _, he := range d.HalfEdges {
+		if he.Prev == nil || he.Prev.Target == nil || he.Target == nil {
+			return fmt.Errorf("half-edge %v has no origin or target", he)
+		}
+		k := key{he.Prev.Target, he.Target}
+		byEnds[k] = append(byEnds[k], he)
+	}
+
+	twins := make(map[*HalfEdge]*HalfEdge, len(d.HalfEdges))
+	for _, he := range d.HalfEdges {
+		if twins[he] != nil {
+			continue
+		}
+		opposite := key{he.Target, he.Prev.Target}
+		candidates := byEnds[opposite]
+		if len(candidates) == 0 || opposite.origin == opposite.target {
+			return fmt.Errorf("half-edge %v has no opposite half-edge to pair with", he)
+		}
+		twin := candidates[0]
+		byEnds[opposite] = candidates[1:]
+		own := key{he.Prev.Target, he.Target}
+		for i, e := range byEnds[own] {
+			if e == he {
+				byEnds[own] = append(byEnds[own][:i:i], byEnds[own][i+1:]...)
+				break
+			}
+		}
+		twins[he], twins[twin] = twin, he
+	}
+
+	defer d.beginOperation(true)()
+	for he, twin := range twins {
+		he.Twin = twin
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package dcel
+
+import (
+	"math"
+	"math/rand"
+)
+
+// poissonAttempts is the number of candidates PoissonSample tries around each point before giving
+// up on it.
+const poissonAttempts = 30
+
+// PoissonSample returns evenly spread random points strictly inside the face and outside its holes,
+// no two of them closer than radius to each other, using Bridson's algorithm for Poisson-disk
+// sampling. Starting from a random point inside the face, candidates are drawn at a distance
+// between radius and twice radius from the points found so far, and kept if they lie inside the
+// face and far enough from every other point, which is checked with a background grid, until no
+// point has room for new ones nearby. As coordinates are integers, candidates are rounded to the
+// nearest integer point before they are checked. The points are returned in the order they were
+// found, and the same seed always gives the same points. Parts of the face that are only reached
+// through passages narrower than radius may be left empty. Nil is returned if radius is not
+// positive, or if no point inside the face is found.
+func (f *Face) PoissonSample(radius float64, seed int64) [][2]int {
+	outer := f.boundary()
+	if radius <= 0 || len(outer) == 0 {
+		return nil
+	}
+	rng := rand.New(rand.NewSource(seed))
+	minX, minY, maxX, maxY := f.BoundingBox()
+	inside := func(x, y int) bool {
+		return f.classifyScaled(outer, &Vertex{X: x, Y: y}, 1) > 0
+	}
+
+	// Every cell of the background grid holds at most one point, as its diagonal is radius long
+	size := radius / math.Sqrt2
+	cols := int(float64(maxX-minX)/size) + 1
+	rows := int(float64(maxY-minY)/size) + 1
+	grid := make(map[[2]int]int)
+	cell := func(x, y int) [2]int {
+		return [2]int{int(float64(x-minX) / size), int(float64(y-minY) / size)}
+	}
+	var points [][2]int
+	fits := func(x, y int) bool {
+		if x < minX || x > maxX || y < minY || y > maxY || !inside(x, y) {
+			return false
+		}
+		c := cell(x, y)
+		for j := max(c[1]-2, 0); j <= min(c[1]+2, rows-1); j++ {
+			for i := max(c[0]-2, 0); i <= min(c[0]+2, cols-1); i++ {
+				if k, ok := grid[[2]int{i, j}]; ok {
+					p := points[k]
+					if math.Hypot(float64(p[0]-x), float64(p[1]-y)) < radius {
+						return false
+					}
+				}
+			}
+		}
+		return true
+	}
+	add := func(x, y int) {
+		grid[cell(x, y)] = len(points)
+		points = append(points, [2]int{x, y})
+	}
+
+	// Start from a random point inside the face, or from the interior point if none is hit
+	for i := 0; i < poissonAttempts && len(points) == 0; i++ {
+		x, y := minX+rng.Intn(maxX-minX+1), minY+rng.Intn(maxY-minY+1)
+		if inside(x, y) {
+			add(x, y)
+		}
+	}
+	if len(points) == 0 {
+		x, y := f.InteriorPoint()
+		if rx, ry := int(math.Round(x)), int(math.Round(y)); inside(rx, ry) {
+			add(rx, ry)
+		} else {
+			return nil
+		}
+	}
+
+	active := []int{0}
+	for len(active) > 0 {
+		i := rng.Intn(len(active))
+		p := points[active[i]]
+		found := false
+		for k := 0; k < poissonAttempts; k++ {
+			angle := 2 * math.Pi * rng.Float64()
+			dist := radius * (1 + rng.Float64())
+			x := int(math.Round(float64(p[0]) + dist*math.Cos(angle)))
+			y := int(math.Round(float64(p[1]) + dist*math.Sin(angle)))
+			if fits(x, y) {
+				active = append(active, len(points))
+				add(x, y)
+				found = true
+				break
+			}
+		}
+		if !found {
+			active[i] = active[len(active)-1]
+			active = active[:len(active)-1]
+		}
+	}
+	return points
+}
@@ -0,0 +1,101 @@
+package dcel
+
+import "errors"
+
+// SimplifyRDP simplifies the boundary of the face with the Ramer-Douglas-Peucker algorithm,
+// removing the boundary vertices that lie within tolerance of the simplified outline. Vertices
+// shared with more than one other face are always kept, and the boundary is simplified separately
+// between each two of them, so the neighbouring faces remain consistent with the new outline.
+// Removed vertices are dissolved by merging their two edges, which also updates the twin
+// half-edges, and no vertex is removed if that would leave this or the neighbouring face with less
+// than three vertices.
+//
+// An error is returned if the face does not belong to a structure, i.e. was not created by
+// NewFace or RebuildFaces, or if it has an open boundary or less than three vertices.
+func (f *Face) SimplifyRDP(tolerance float64) error {
+	d := f.dcel
+	if d == nil {
+		return errors.New("face does not belong to a structure")
+	}
+	defer d.beginOperation(true)()
+	edges := f.boundary()
+	if len(edges) < 3 {
+		return errors.New("face boundary is not a closed cycle of at least three vertices")
+	}
+	vertices := f.Vertices()
+
+	n := len(vertices)
+	keep := make([]bool, n)
+	var anchors []int
+	for i, v := range vertices {
+		if v.Degree() != 2 {
+			keep[i] = true
+			anchors = append(anchors, i)
+		}
+	}
+	if len(anchors) < 2 {
+		// Split a free-standing ring at its first vertex and the vertex farthest from it
+		first := 0
+		if len(anchors) == 1 {
+			first = anchors[0]
+		}
+		far, farDist := first, -1.0
+		for i, v := range vertices {
+			if dist := distance(v, vertices[first]); dist > farDist {
+				far, farDist = i, dist
+			}
+		}
+		keep[first], keep[far] = true, true
+		anchors = []int{first, far}
+		if far < first {
+			anchors[0], anchors[1] = far, first
+		}
+	}
+
+	for k, start := range anchors {
+		end := anchors[(k+1)%len(anchors)]
+		if end <= start {
+			end += n
+		}
+		simplifyChain(vertices, start, end, tolerance, keep)
+	}
+
+	removedVertices := make(map[*Vertex]bool)
+	removedEdges := make(map[*HalfEdge]bool)
+	for i, v := range vertices {
+		if keep[i] {
+			continue
+		}
+		if removed := dissolveVertex(v); removed != nil {
+			removedVertices[v] = true
+			for _, he := range removed {
+				removedEdges[he] = true
+			}
+		}
+	}
+	d.remove(removedVertices, removedEdges, nil)
+	return nil
+}
+
+// simplifyChain marks the vertices to keep from the chain of boundary vertices between the
+// indices start and end, which may wrap around the end of the slice. The endpoints of the chain
+// are expected to be kept by the caller.
+func simplifyChain(vertices []*Vertex, start, end int, tolerance float64, keep []bool) {
+	if end-start < 2 {
+		return
+	}
+	n := len(vertices)
+	a, b := vertices[start%n], vertices[end%n]
+	farthest, farDist := -1, tolerance
+	for i := start + 1; i < end; i++ {
+		if dist := distanceToSegment(vertices[i%n], a, b); dist > farDist {
+			farthest, farDist = i, dist
+		}
+	}
+	if farthest < 0 {
+		return
+	}
+	keep[farthest%n] = true
+	simplifyChain(vertices, start, farthest, tolerance, keep)
+	simplifyChain(vertices, farthest, end, tolerance, keep)
+}
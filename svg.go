@@ -0,0 +1,95 @@
+package dcel
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// SVGOptions controls how a structure is drawn as SVG.
+type SVGOptions struct {
+	// Scale multiplies all coordinates. Zero means 1.
+	Scale float64
+	// Margin is the space left around the drawing, in SVG units.
+	Margin float64
+	// FlipY makes the y axis point upwards, as it does in the plane, instead of downwards.
+	FlipY bool
+	// Stroke and Fill are the colors of the edges and of the faces. They default to "black" and
+	// "none".
+	Stroke, Fill string
+	// StrokeWidth is the width of the edges, in SVG units. Zero means 1.
+	StrokeWidth float64
+}
+
+// WriteSVGPaths writes the structure to w as an SVG document, with one path element per bounded
+// face, so that every face can be styled and scripted on its own. The outer boundary and the holes
+// of a face are subpaths of the same path, filled with the even-odd rule so the holes are left
+// empty. Each path carries the ID of its face as an id attribute of the form "face-<ID>". Faces
+// without a closed boundary are skipped. The returned error is the first one reported by w.
+func (d *DCEL) WriteSVGPaths(w io.Writer, opts SVGOptions) error {
+	scale, strokeWidth := opts.Scale, opts.StrokeWidth
+	if scale == 0 {
+		scale = 1
+	}
+	if strokeWidth == 0 {
+		strokeWidth = 1
+	}
+	stroke, fill := opts.Stroke, opts.Fill
+	if stroke == "" {
+		stroke = "black"
+	}
+	if fill == "" {
+		fill = "none"
+	}
+
+	var minX, minY, maxX, maxY int
+	for i, v := range d.Vertices {
+		if i == 0 {
+			minX, minY, maxX, maxY = v.X, v.Y, v.X, v.Y
+		}
+		minX, minY = min(minX, v.X), min(minY, v.Y)
+		maxX, maxY = max(maxX, v.X), max(maxY, v.Y)
+	}
+	point := func(v *Vertex) (float64, float64) {
+		x, y := float64(v.X-minX), float64(v.Y-minY)
+		if opts.FlipY {
+			y = float64(maxY - v.Y)
+		}
+		return x*scale + opts.Margin, y*scale + opts.Margin
+	}
+
+	var b strings.Builder
+	width := float64(maxX-minX)*scale + 2*opts.Margin
+	height := float64(maxY-minY)*scale + 2*opts.Margin
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%g" height="%g" viewBox="0 0 %g %g">`+"\n",
+		width, height, width, height)
+	for _, face := range d.boundedFaces() {
+		rings := [][]*HalfEdge{face.boundary()}
+		if len(rings[0]) == 0 {
+			continue
+		}
+		for _, hole := range face.InnerComponents {
+			if edges := closedCycle(hole); len(edges) > 0 {
+				rings = append(rings, edges)
+			}
+		}
+		var path []string
+		for _, ring := range rings {
+			for i, he := range ring {
+				x, y := point(he.Target)
+				command := "L"
+				if i == 0 {
+					command = "M"
+				}
+				path = append(path, fmt.Sprintf("%s%g %g", command, x, y))
+			}
+			path = append(path, "Z")
+		}
+		fmt.Fprintf(&b, `  <path id="face-%d" d="%s" fill="%s" fill-rule="evenodd" stroke="%s" stroke-width="%g"/>`+"\n",
+			face.ID, strings.Join(path, " "), fill, stroke, strokeWidth)
+	}
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
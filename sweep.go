@@ -0,0 +1,331 @@
+package dcel
+
+import (
+	"container/heap"
+	"math/big"
+	"sort"
+)
+
+// sweepSegment is an edge taking part in the sweep of sweepIntersections, with its endpoints in
+// the order in which the sweep line reaches them: left before right, and for a vertical edge the
+// lower endpoint first.
+type sweepSegment struct {
+	he          *HalfEdge
+	left, right *Vertex
+	id          int
+}
+
+// sweepEvent is a point at which the sweep line stops, with exact rational coordinates, as the
+// crossing point of two integer segments need not have integer coordinates.
+type sweepEvent struct {
+	x, y *big.Rat
+	key  string
+}
+
+// sweepIntersections finds the points where the given edges cross or touch each other with the
+// Bentley-Ottmann algorithm. A vertical sweep line moves from left to right over an event queue,
+// which initially holds the endpoints of the edges, while a status structure keeps the edges
+// crossing the sweep line ordered from bottom to top. When two edges become neighbours in the
+// status, their crossing point to the right of the sweep line is added to the queue, so every
+// crossing is found when the sweep reaches it. All n edges and k meeting points are handled in
+// O((n + k) log n) time. The coordinates of the points are computed exactly, and degenerate input
+// is handled as in de Berg et al.: edges meeting at a common endpoint, endpoints lying on other
+// edges, vertical edges and overlapping collinear edges.
+//
+// The report function is called for every point where at least two edges meet and at least one of
+// them passes through the point rather than ending there, with the half-edges of those passing
+// edges. Points are reported in the order the sweep line reaches them, and edges whose endpoints
+// coincide are only taken into account as points lying on other edges.
+func sweepIntersections(edges []*HalfEdge, report func(x, y *big.Rat, interior []*HalfEdge)) {
+	queue := &sweepQueue{}
+	queued := make(map[string]bool)
+	push := func(x, y *big.Rat) {
+		e := &sweepEvent{x: x, y: y, key: x.RatString() + " " + y.RatString()}
+		if !queued[e.key] {
+			queued[e.key] = true
+			heap.Push(queue, e)
+		}
+	}
+	starts := make(map[string][]*sweepSegment)
+	points := make(map[string]int)
+	for i, he := range edges {
+		a, b := he.Origin(), he.Target
+		if b.X < a.X || b.X == a.X && b.Y < a.Y {
+			a, b = b, a
+		}
+		x, y := ratInt(a.X), ratInt(a.Y)
+		push(x, y)
+		key := x.RatString() + " " + y.RatString()
+		if a.X == b.X && a.Y == b.Y {
+			points[key]++
+			continue
+		}
+		starts[key] = append(starts[key], &sweepSegment{he: he, left: a, right: b, id: i})
+		push(ratInt(b.X), ratInt(b.Y))
+	}
+
+	status := &sweepStatus{seed: 1}
+	check := func(s, t *sweepSegment, p *sweepEvent) {
+		if x, y, ok := segmentCrossing(s, t); ok && comparePoints(x, y, p.x, p.y) > 0 {
+			push(x, y)
+		}
+	}
+	for queue.Len() > 0 {
+		p := heap.Pop(queue).(*sweepEvent)
+
+		// The edges in the status containing p follow each other, ending at or passing through it
+		i := status.lowerBound(func(s *sweepSegment) bool { return compareAtSweep(s, p) >= 0 })
+		var through, continuing []*sweepSegment
+		var interior []*HalfEdge
+		for j := i; j < status.len(); j++ {
+			s := status.at(j)
+			if compareAtSweep(s, p) != 0 {
+				break
+			}
+			through = append(through, s)
+			if !pointAt(s.right, p) {
+				continuing = append(continuing, s)
+				interior = append(interior, s.he)
+			}
+		}
+		upper := starts[p.key]
+		if len(interior) > 0 && len(through)+len(upper)+points[p.key] > 1 {
+			report(p.x, p.y, interior)
+		}
+
+		// Reinsert the edges continuing past p in the order they have right of it, by slope
+		status.removeRange(i, len(through))
+		continuing = append(continuing, upper...)
+		sort.Slice(continuing, func(a, b int) bool { return slopeLess(continuing[a], continuing[b]) })
+		status.insertAt(i, continuing)
+
+		if len(continuing) == 0 {
+			if i > 0 && i < status.len() {
+				check(status.at(i-1), status.at(i), p)
+			}
+			continue
+		}
+		if i > 0 {
+			check(status.at(i-1), continuing[0], p)
+		}
+		if j := i + len(continuing); j < status.len() {
+			check(continuing[len(continuing)-1], status.at(j), p)
+		}
+	}
+}
+
+// ratInt returns the integer as a rational number.
+func ratInt(x int) *big.Rat {
+	return new(big.Rat).SetInt64(int64(x))
+}
+
+// pointAt returns true if the vertex lies at the event point.
+func pointAt(v *Vertex, p *sweepEvent) bool {
+	return p.x.Cmp(ratInt(v.X)) == 0 && p.y.Cmp(ratInt(v.Y)) == 0
+}
+
+// comparePoints compares two points in the order in which the sweep line reaches them: by x, and
+// by y for points with the same x. It returns -1, 0 or 1 like big.Rat.Cmp.
+func comparePoints(ax, ay, bx, by *big.Rat) int {
+	if c := ax.Cmp(bx); c != 0 {
+		return c
+	}
+	return ay.Cmp(by)
+}
+
+// compareAtSweep compares the height of the segment at the x coordinate of the event point with
+// the height of the point, returning -1 if the segment passes below it, 0 if it passes through it
+// and 1 if it passes above it. A vertical segment covers all heights between its endpoints, so it
+// is compared by the one closest to the point.
+func compareAtSweep(s *sweepSegment, p *sweepEvent) int {
+	if s.left.X == s.right.X {
+		switch {
+		case p.y.Cmp(ratInt(s.left.Y)) < 0:
+			return 1
+		case p.y.Cmp(ratInt(s.right.Y)) > 0:
+			return -1
+		}
+		return 0
+	}
+	// y = left.Y + (p.x - left.X) * dy / dx
+	y := new(big.Rat).Sub(p.x, ratInt(s.left.X))
+	y.Mul(y, big.NewRat(int64(s.right.Y-s.left.Y), int64(s.right.X-s.left.X)))
+	y.Add(y, ratInt(s.left.Y))
+	return y.Cmp(p.y)
+}
+
+// slopeLess orders segments passing through a common point as they follow each other just right of
+// the point, from bottom to top: by increasing slope, with vertical segments last. Collinear
+// segments are ordered by their position in the input.
+func slopeLess(s, t *sweepSegment) bool {
+	sdx, sdy := int64(s.right.X-s.left.X), int64(s.right.Y-s.left.Y)
+	tdx, tdy := int64(t.right.X-t.left.X), int64(t.right.Y-t.left.Y)
+	if (sdx == 0) != (tdx == 0) {
+		return tdx == 0
+	}
+	if c := sdy*tdx - tdy*sdx; sdx != 0 && c != 0 {
+		return c < 0
+	}
+	return s.id < t.id
+}
+
+// segmentCrossing returns the exact point where the two segments meet, or false if they do not
+// meet or are collinear. Collinear segments meet at an endpoint of one of them, which is an event
+// of the sweep anyway.
+func segmentCrossing(s, t *sweepSegment) (*big.Rat, *big.Rat, bool) {
+	a, b, c, d := s.left, s.right, t.left, t.right
+	if !segmentsIntersect(a, b, c, d) {
+		return nil, nil, false
+	}
+	rx, ry := int64(b.X-a.X), int64(b.Y-a.Y)
+	sx, sy := int64(d.X-c.X), int64(d.Y-c.Y)
+	denom := big.NewInt(rx*sy - ry*sx)
+	if denom.Sign() == 0 {
+		return nil, nil, false
+	}
+	// The point is a + t*(b - a) with t = num / denom
+	num := big.NewInt(int64(c.X-a.X)*sy - int64(c.Y-a.Y)*sx)
+	coord := func(origin int, delta int64) *big.Rat {
+		v := new(big.Int).Mul(big.NewInt(int64(origin)), denom)
+		v.Add(v, new(big.Int).Mul(num, big.NewInt(delta)))
+		return new(big.Rat).SetFrac(v, denom)
+	}
+	return coord(a.X, rx), coord(a.Y, ry), true
+}
+
+// sweepQueue is the event queue of the sweep, a min-heap of events in sweep order, implementing
+// heap.Interface.
+type sweepQueue []*sweepEvent
+
+func (q sweepQueue) Len() int { return len(q) }
+func (q sweepQueue) Less(i, j int) bool {
+	return comparePoints(q[i].x, q[i].y, q[j].x, q[j].y) < 0
+}
+func (q sweepQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *sweepQueue) Push(x interface{}) { *q = append(*q, x.(*sweepEvent)) }
+func (q *sweepQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// sweepStatus is the status structure of the sweep, the sequence of segments crossing the sweep
+// line from bottom to top. It is a treap keyed by position, so segments are inserted, removed and
+// looked up by their index in O(log n) expected time, while the index of a segment is found by
+// binary search with a predicate that holds for a suffix of the sequence.
+type sweepStatus struct {
+	root *statusNode
+	seed uint32
+}
+
+type statusNode struct {
+	seg         *sweepSegment
+	priority    uint32
+	size        int
+	left, right *statusNode
+}
+
+func (n *statusNode) count() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *statusNode) update() *statusNode {
+	n.size = 1 + n.left.count() + n.right.count()
+	return n
+}
+
+func (s *sweepStatus) len() int {
+	return s.root.count()
+}
+
+// at returns the segment at index i.
+func (s *sweepStatus) at(i int) *sweepSegment {
+	n := s.root
+	for {
+		switch left := n.left.count(); {
+		case i < left:
+			n = n.left
+		case i == left:
+			return n.seg
+		default:
+			i -= left + 1
+			n = n.right
+		}
+	}
+}
+
+// lowerBound returns the index of the first segment for which pred returns true, or the number of
+// segments if there is none.
+func (s *sweepStatus) lowerBound(pred func(s *sweepSegment) bool) int {
+	index := 0
+	for n := s.root; n != nil; {
+		if pred(n.seg) {
+			n = n.left
+		} else {
+			index += n.left.count() + 1
+			n = n.right
+		}
+	}
+	return index
+}
+
+// removeRange removes count segments starting at index i.
+func (s *sweepStatus) removeRange(i, count int) {
+	if count == 0 {
+		return
+	}
+	before, rest := splitStatus(s.root, i)
+	_, after := splitStatus(rest, count)
+	s.root = mergeStatus(before, after)
+}
+
+// insertAt inserts the segments in the given order before the segment at index i.
+func (s *sweepStatus) insertAt(i int, segments []*sweepSegment) {
+	if len(segments) == 0 {
+		return
+	}
+	var middle *statusNode
+	for _, seg := range segments {
+		// A xorshift generator is enough to balance the treap
+		s.seed ^= s.seed << 13
+		s.seed ^= s.seed >> 17
+		s.seed ^= s.seed << 5
+		middle = mergeStatus(middle, &statusNode{seg: seg, priority: s.seed, size: 1})
+	}
+	before, after := splitStatus(s.root, i)
+	s.root = mergeStatus(mergeStatus(before, middle), after)
+}
+
+// splitStatus splits the treap into its first k nodes and the rest.
+func splitStatus(n *statusNode, k int) (*statusNode, *statusNode) {
+	if n == nil {
+		return nil, nil
+	}
+	if n.left.count() >= k {
+		left, right := splitStatus(n.left, k)
+		n.left = right
+		return left, n.update()
+	}
+	left, right := splitStatus(n.right, k-n.left.count()-1)
+	n.right = left
+	return n.update(), right
+}
+
+// mergeStatus joins two treaps, all nodes of a coming before the ones of b.
+func mergeStatus(a, b *statusNode) *statusNode {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case a.priority > b.priority:
+		a.right = mergeStatus(a.right, b)
+		return a.update()
+	}
+	b.left = mergeStatus(a, b.left)
+	return b.update()
+}
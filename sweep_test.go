@@ -0,0 +1,76 @@
+package dcel
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// pairwiseContacts returns, for every edge, the points in its interior where another edge meets
+// it, found by testing every pair of edges.
+func pairwiseContacts(edges []*HalfEdge) map[*HalfEdge]map[string]bool {
+	contacts := make(map[*HalfEdge]map[string]bool)
+	add := func(he *HalfEdge, x, y *big.Rat) {
+		if contacts[he] == nil {
+			contacts[he] = make(map[string]bool)
+		}
+		contacts[he][x.RatString()+" "+y.RatString()] = true
+	}
+	for i, e := range edges {
+		for j, f := range edges {
+			if i == j {
+				continue
+			}
+			a, b, c, d := e.Origin(), e.Target, f.Origin(), f.Target
+			for _, v := range []*Vertex{c, d} {
+				if onSegment(v, a, b) {
+					add(e, ratInt(v.X), ratInt(v.Y))
+				}
+			}
+			if segmentsCross(a, b, c, d) {
+				x, y, _ := segmentCrossing(&sweepSegment{left: a, right: b}, &sweepSegment{left: c, right: d})
+				add(e, x, y)
+			}
+		}
+	}
+	return contacts
+}
+
+func TestSweepIntersectionsMatchesPairwise(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	for trial := 0; trial < 300; trial++ {
+		// A small range makes shared endpoints, vertical, collinear and overlapping edges common
+		size := 4 + trial%12
+		d := NewDCEL()
+		for len(d.HalfEdges) < 2*(5+trial%20) {
+			a := d.NewVertex(rng.Intn(size), rng.Intn(size))
+			b := d.NewVertex(rng.Intn(size), rng.Intn(size))
+			if trial%3 == 0 {
+				b.X = a.X
+			}
+			he, tw := d.addHalfEdge(nil, b), d.addHalfEdge(nil, a)
+			he.Twin, tw.Twin = tw, he
+		}
+		edges := d.edges()
+		want := pairwiseContacts(edges)
+		got := make(map[*HalfEdge]map[string]bool)
+		sweepIntersections(edges, func(x, y *big.Rat, interior []*HalfEdge) {
+			for _, he := range interior {
+				if got[he] == nil {
+					got[he] = make(map[string]bool)
+				}
+				got[he][x.RatString()+" "+y.RatString()] = true
+			}
+		})
+		for _, he := range edges {
+			if len(got[he]) != len(want[he]) {
+				t.Fatalf("trial %d: edge %v meets others at %v, want %v", trial, he, got[he], want[he])
+			}
+			for p := range want[he] {
+				if !got[he][p] {
+					t.Fatalf("trial %d: edge %v meets others at %v, want %v", trial, he, got[he], want[he])
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package dcel
+
+import (
+	"errors"
+	"sort"
+)
+
+// angleLess reports whether the direction of the vector (ax, ay) comes before the direction of
+// (bx, by) when sorted by angle counter-clockwise from the positive x axis. The comparison is exact
+// for integer vectors.
+func angleLess(ax, ay, bx, by int) bool {
+	upper := func(x, y int) bool {
+		return y > 0 || y == 0 && x > 0
+	}
+	if upper(ax, ay) != upper(bx, by) {
+		return upper(ax, ay)
+	}
+	return int64(ax)*int64(by)-int64(ay)*int64(bx) > 0
+}
+
+// sortByAngle sorts half-edges going out of a common origin counter-clockwise by the angle of
+// their direction, starting from the positive x axis.
+func sortByAngle(edges []*HalfEdge) {
+	sort.SliceStable(edges, func(i, j int) bool {
+		a, b := edges[i], edges[j]
+		ao, bo := a.Origin(), b.Origin()
+		return angleLess(a.Target.X-ao.X, a.Target.Y-ao.Y, b.Target.X-bo.X, b.Target.Y-bo.Y)
+	})
+}
+
+// RebuildFaces discards the Next and Prev links of all half-edges and derives them again from the
+// geometry of the edges, by sorting the edges around each vertex by angle. Each resulting
+// counter-clockwise cycle of half-edges becomes a bounded face: an existing face is reused for
+// the first such cycle containing one of its half-edges, and faces left without a boundary are
+// removed. The clockwise cycle enclosing the largest area becomes the boundary of OuterFace, while
+// every other clockwise cycle, which runs around a separate connected component, becomes one of
+// the InnerComponents of the smallest bounded face containing it, or of OuterFace if there is
+// none. The previous InnerComponents of the reused faces are discarded.
+//
+// An error is returned if a half-edge has no twin or no target vertex.
+func (d *DCEL) RebuildFaces() error {
+	defer d.beginOperation(true)()
+	outgoing := make(map[*Vertex][]*HalfEdge)
+	for _, he := range d.HalfEdges {
+		if he.Twin == nil || he.Target == nil || he.Twin.Target == nil {
+			return errors.New("half-edge has no twin or target vertex")
+		}
+		origin := he.Origin()
+		outgoing[origin] = append(outgoing[origin], he)
+	}
+	linkByAngle(d.Vertices, outgoing)
+
+	claimed := make(map[*Face]bool)
+	visited := make(map[*HalfEdge]bool)
+	var faces []*Face
+	var outer *Face
+	var outerArea float64
+	for _, start := range d.HalfEdges {
+		if visited[start] {
+			continue
+		}
+		var cycle []*HalfEdge
+		for he := start; !visited[he]; he = he.Next {
+			visited[he] = true
+			cycle = append(cycle, he)
+		}
+
+		// A bounded face is only reused for a counter-clockwise cycle, as the clockwise ones become
+		// holes of other faces
+		var face *Face
+		area := ringArea(cycle)
+		for _, he := range cycle {
+			if he.Face != nil && !claimed[he.Face] && (area > 0 || he.Face == d.OuterFace) {
+				face = he.Face
+				break
+			}
+		}
+		if face == nil {
+			face = &Face{}
+		}
+		claimed[face] = true
+		face.HalfEdge = start
+		face.InnerComponents = nil
+		face.dcel = d
+		for _, he := range cycle {
+			he.Face = face
+		}
+		faces = append(faces, face)
+
+		if area < outerArea {
+			outer, outerArea = face, area
+		}
+	}
+
+	d.Faces = faces
+	d.OuterFace = outer
+	if outer != nil {
+		d.nestComponents()
+	}
+	return nil
+}
+
+// mergeIntoOuter removes the given bounded faces, moving their outer boundaries and their holes
+// into the InnerComponents of the OuterFace.
+func (d *DCEL) mergeIntoOuter(faces map[*Face]bool) {
+	for _, f := range d.Faces {
+		if !faces[f] {
+			continue
+		}
+		for _, start := range append([]*HalfEdge{f.HalfEdge}, f.InnerComponents...) {
+			for _, he := range cycle(start) {
+				he.Face = d.OuterFace
+			}
+			d.OuterFace.InnerComponents = append(d.OuterFace.InnerComponents, start)
+		}
+	}
+	d.remove(nil, nil, faces)
+}
+
+// nestComponents links the boundaries of separate connected components, which the tracing of
+// RebuildFaces makes faces of their own, to the faces they lie in. The clockwise boundary around a component that
+// lies inside a counter-clockwise face becomes one of the InnerComponents of the smallest such
+// face, and every other clockwise boundary is merged into the OuterFace as one of its
+// InnerComponents. The faces of the merged boundaries are removed.
+func (d *DCEL) nestComponents() {
+	var bounded, around []*Face
+	for _, f := range d.Faces {
+		switch {
+		case f == d.OuterFace:
+		case ringArea(f.HalfEdges()) > 0:
+			bounded = append(bounded, f)
+		default:
+			around = append(around, f)
+		}
+	}
+
+	removed := make(map[*Face]bool, len(around))
+	for _, g := range around {
+		// Separate components do not touch, so any vertex of g tells whether it is inside a face
+		parent := d.OuterFace
+		var parentArea float64
+		v := g.HalfEdge.Target
+		for _, f := range bounded {
+			edges := f.HalfEdges()
+			if area := ringArea(edges); (parent == d.OuterFace || area < parentArea) && classifyRing(edges, v) > 0 {
+				parent, parentArea = f, area
+			}
+		}
+		for _, he := range g.HalfEdges() {
+			he.Face = parent
+		}
+		parent.InnerComponents = append(parent.InnerComponents, g.HalfEdge)
+		removed[g] = true
+	}
+	d.remove(nil, nil, removed)
+}
+
+// linkByAngle sorts the half-edges going out of each vertex, as listed in outgoing, by angle and
+// sets their Next and Prev links, so that every face continues with the next edge clockwise around
+// the vertex. Each vertex is pointed at one of its incoming half-edges, or at nil if outgoing has
+// none for it.
+func linkByAngle(vertices []*Vertex, outgoing map[*Vertex][]*HalfEdge) {
+	for _, v := range vertices {
+		v.HalfEdge = nil
+	}
+	for v, edges := range outgoing {
+		sortByAngle(edges)
+		for i, e := range edges {
+			// The face to the left of the incoming twin continues with the next edge clockwise
+			prev := edges[(i+len(edges)-1)%len(edges)]
+			e.Twin.Next = prev
+			prev.Prev = e.Twin
+		}
+		v.HalfEdge = edges[0].Twin
+	}
+}
@@ -0,0 +1,35 @@
+package dcel
+
+import "testing"
+
+func TestRebuildFacesKeepsFacesWithHoles(t *testing.T) {
+	d, err := FromRaster([][]bool{{true, true, true}, {true, false, true}, {true, true, true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ring := d.boundedFaces()[0]
+	ring.Data = "ring"
+	// Tracing the cycle of the hole first must not hand the face over to it
+	hole := cycle(ring.InnerComponents[0])
+	inHole := make(map[*HalfEdge]bool)
+	for _, he := range hole {
+		inHole[he] = true
+	}
+	halfEdges := append([]*HalfEdge(nil), hole...)
+	for _, he := range d.HalfEdges {
+		if !inHole[he] {
+			halfEdges = append(halfEdges, he)
+		}
+	}
+	d.HalfEdges = halfEdges
+
+	if err := d.RebuildFaces(); err != nil {
+		t.Fatal(err)
+	}
+	if ring.Area() != 9 || len(ring.InnerComponents) != 1 {
+		t.Errorf("face has area %v and %d holes, want 9 and 1", ring.Area(), len(ring.InnerComponents))
+	}
+	if err := d.ValidateHoles(); err != nil {
+		t.Error(err)
+	}
+}
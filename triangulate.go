@@ -0,0 +1,52 @@
+package dcel
+
+import "errors"
+
+// TriangulateConvexFace splits a convex face into triangles, by connecting the origin of
+// f.HalfEdge to every other boundary vertex that is not already adjacent to it, and returns the
+// triangles in counter-clockwise fan order around that vertex. The face itself is reused as the
+// last triangle, and the new faces and diagonals get no ID or Data. An error is returned if the
+// face is not convex, or if its boundary goes straight on at any vertex, as some of the triangles
+// would be degenerate.
+func (d *DCEL) TriangulateConvexFace(f *Face) ([]*Face, error) {
+	if !f.IsConvex() {
+		return nil, errors.New("face is not convex")
+	}
+	edges := f.boundary()
+	for i, he := range edges {
+		if orient(he.Origin(), he.Target, edges[(i+1)%len(edges)].Target) == 0 {
+			return nil, errors.New("face has collinear boundary vertices")
+		}
+	}
+
+	defer d.beginOperation(true)()
+	last := edges[len(edges)-1]
+	triangles := make([]*Face, 0, len(edges)-2)
+	for _, he := range edges[1 : len(edges)-2] {
+		triangles = append(triangles, d.splitFace(last, he))
+	}
+	return append(triangles, f), nil
+}
+
+// splitFace connects the targets of two distinct half-edges at the boundary of the same face with
+// a new edge, and returns the new face made of the boundary half-edges following a up to b. The
+// original face keeps the rest of its boundary. The caller must make sure that the new edge lies
+// inside the face.
+func (d *DCEL) splitFace(a, b *HalfEdge) *Face {
+	f := a.Face
+	g := d.NewFace()
+	toB := d.addHalfEdge(f, b.Target)
+	toA := d.addHalfEdge(g, a.Target)
+	toB.Twin, toA.Twin = toA, toB
+
+	aNext, bNext := a.Next, b.Next
+	a.Next, toB.Prev = toB, a
+	toB.Next, bNext.Prev = bNext, toB
+	b.Next, toA.Prev = toA, b
+	toA.Next, aNext.Prev = aNext, toA
+	for he := aNext; he != toA; he = he.Next {
+		he.Face = g
+	}
+	f.HalfEdge, g.HalfEdge = toB, toA
+	return g
+}
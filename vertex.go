@@ -0,0 +1,98 @@
+package dcel
+
+import "math"
+
+// incoming returns the half-edges that have the vertex as their target, found by rotating around
+// the vertex starting from v.HalfEdge. If the rotation is interrupted by a missing pointer, the
+// remaining half-edges are collected by rotating in the opposite direction.
+func (v *Vertex) incoming() []*HalfEdge {
+	if v.HalfEdge == nil {
+		return nil
+	}
+	var edges []*HalfEdge
+	visited := make(map[*HalfEdge]bool)
+	he := v.HalfEdge
+	for he != nil && !visited[he] {
+		visited[he] = true
+		edges = append(edges, he)
+		if he.Next == nil {
+			he = nil
+			break
+		}
+		he = he.Next.Twin
+	}
+	if he == nil {
+		// Rotate the other way round, from the starting half-edge
+		for e := v.HalfEdge; e.Twin != nil && e.Twin.Prev != nil && !visited[e.Twin.Prev]; {
+			e = e.Twin.Prev
+			visited[e] = true
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
+
+// Degree returns the number of edges incident to the vertex.
+func (v *Vertex) Degree() int {
+	return len(v.incoming())
+}
+
+// outgoing returns the half-edges leaving the vertex that have a target, sorted counter-clockwise
+// by the angle of their direction.
+func (v *Vertex) outgoing() []*HalfEdge {
+	var edges []*HalfEdge
+	for _, he := range v.incoming() {
+		if he.Twin != nil && he.Twin.Target != nil {
+			edges = append(edges, he.Twin)
+		}
+	}
+	sortByAngle(edges)
+	return edges
+}
+
+// OutgoingNearestDirection returns the half-edge leaving the vertex whose direction is angularly
+// closest to the given angle, in radians counter-clockwise from the positive x axis. If two
+// half-edges are equally close, the one that comes first counter-clockwise from the x axis is
+// returned. Nil is returned if no half-edge leaves the vertex.
+func (v *Vertex) OutgoingNearestDirection(angle float64) *HalfEdge {
+	var nearest *HalfEdge
+	nearestDiff := math.Inf(1)
+	for _, he := range v.outgoing() {
+		direction := math.Atan2(float64(he.Target.Y-v.Y), float64(he.Target.X-v.X))
+		diff := math.Abs(math.Remainder(direction-angle, 2*math.Pi))
+		if diff < nearestDiff {
+			nearest, nearestDiff = he, diff
+		}
+	}
+	return nearest
+}
+
+// AngleDefect returns the angle defect of the vertex, the discrete analogue of Gaussian
+// curvature: 2π minus the sum of the corner angles, as given by InteriorAngle, of the faces
+// incident to the vertex. A vertex on the boundary of the subdivision, i.e. one with an incident
+// face corner that belongs to no face or to the OuterFace of its structure, uses π minus the
+// angle sum of its other corners instead, so a vertex along a straight stretch of the boundary
+// has no defect, while a convex corner of the boundary has a positive one and a reflex corner a
+// negative one. The boundary is told by the OuterFace marker rather than by orientation, so the
+// bounded faces may run either way. As the faces of a planar subdivision fill the plane around an
+// interior vertex, the defect of such a vertex is zero up to rounding errors. Zero is returned for
+// a vertex without incident edges.
+func (v *Vertex) AngleDefect() float64 {
+	edges := v.incoming()
+	if len(edges) == 0 {
+		return 0
+	}
+	sum := 0.0
+	boundary := false
+	for _, he := range edges {
+		if he.Face == nil || isOuter(he.Face) {
+			boundary = true
+			continue
+		}
+		sum += he.InteriorAngle()
+	}
+	if boundary {
+		return math.Pi - sum
+	}
+	return 2*math.Pi - sum
+}
@@ -0,0 +1,68 @@
+package dcel
+
+import (
+	"math"
+	"testing"
+)
+
+// findVertex returns the vertex of the structure at the given coordinates, failing the test if
+// there is none.
+func findVertex(t *testing.T, d *DCEL, x, y int) *Vertex {
+	t.Helper()
+	for _, v := range d.Vertices {
+		if v.X == x && v.Y == y {
+			return v
+		}
+	}
+	t.Fatalf("no vertex at (%d, %d)", x, y)
+	return nil
+}
+
+func TestAngleDefect(t *testing.T) {
+	ccw := BuildGrid(2, 2, 10, 10)
+	// Mirroring the grid makes its cells clockwise and the outer boundary counter-clockwise
+	cw := BuildGrid(2, 2, 10, 10)
+	for _, v := range cw.Vertices {
+		v.X = -v.X
+	}
+	tests := []struct {
+		name string
+		d    *DCEL
+		x, y int
+		want float64
+	}{
+		{"interior", ccw, 10, 10, 0},
+		{"boundary side", ccw, 10, 0, 0},
+		{"boundary corner", ccw, 0, 0, math.Pi / 2},
+		{"boundary corner", ccw, 20, 20, math.Pi / 2},
+		{"clockwise interior", cw, -10, 10, 0},
+		{"clockwise boundary side", cw, 0, 10, 0},
+		{"clockwise boundary corner", cw, -20, 0, math.Pi / 2},
+	}
+	for _, tt := range tests {
+		if got := findVertex(t, tt.d, tt.x, tt.y).AngleDefect(); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s (%d, %d): AngleDefect() = %v, want %v", tt.name, tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+func TestAngleDefectReflexCorner(t *testing.T) {
+	// An L-shaped region of three cells, whose inner corner at (1, 1) is reflex
+	d, err := FromRaster([][]bool{{true, true}, {true, false}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := findVertex(t, d, 1, 1).AngleDefect(); math.Abs(got+math.Pi/2) > 1e-9 {
+		t.Errorf("AngleDefect() = %v, want %v", got, -math.Pi/2)
+	}
+	if got := findVertex(t, d, 0, 0).AngleDefect(); math.Abs(got-math.Pi/2) > 1e-9 {
+		t.Errorf("AngleDefect() = %v, want %v", got, math.Pi/2)
+	}
+}
+
+func TestAngleDefectIsolatedVertex(t *testing.T) {
+	d := NewDCEL()
+	if got := d.NewVertex(1, 2).AngleDefect(); got != 0 {
+		t.Errorf("AngleDefect() = %v, want 0", got)
+	}
+}
@@ -0,0 +1,159 @@
+package dcel
+
+import (
+	"math"
+	"sort"
+)
+
+// VisibilityPolygon returns the boundary of the region of the face that is visible from the point
+// (x, y), i.e. the points of the face that can be connected to (x, y) by a segment that does not
+// cross the boundary of the face or of any of its holes. The vertices of the region are returned
+// in counter-clockwise order, without repeating the first one at the end, and rounded to integer
+// coordinates. If the point lies on the boundary, at a vertex or in the interior of an edge, only
+// the directions pointing into the face are considered and the point itself is a vertex of the
+// region. Nil is returned if the point lies outside of the face or the face has no closed boundary.
+//
+// The region is found with an angular sweep around the point: a ray is cast towards every
+// boundary vertex and slightly to both sides of it, and the nearest boundary point hit by each ray
+// becomes a vertex of the region. This takes quadratic time in the number of boundary edges.
+func (f *Face) VisibilityPolygon(x, y int) [][2]int {
+	if f.Classify(x, y) < 0 {
+		return nil
+	}
+	p := &Vertex{X: x, Y: y}
+	rings := [][]*HalfEdge{f.boundary()}
+	for _, hole := range f.InnerComponents {
+		if edges := closedCycle(hole); len(edges) > 0 {
+			rings = append(rings, edges)
+		}
+	}
+	// The interior of the face lies on the left of its half-edges if its outer boundary is
+	// oriented counter-clockwise, and on their right otherwise.
+	ccw := ringArea(rings[0]) > 0
+
+	var segments [][2]*Vertex
+	start, width := 0.0, 2*math.Pi
+	onBoundary := false
+	for _, ring := range rings {
+		for i, he := range ring {
+			a, b := ring[(i+len(ring)-1)%len(ring)].Target, he.Target
+			if !onBoundary && (b.X == x && b.Y == y || onSegment(p, a, b)) {
+				// The point lies on this edge, or at its target: the visible directions are the
+				// ones between the edge (or the next one) and the reverse of the edge.
+				from, to := he.Next.Target, a
+				if onSegment(p, a, b) {
+					from = b
+				}
+				if !ccw {
+					from, to = to, from
+				}
+				start = direction(p, from)
+				width = normalizeAngle(direction(p, to) - start)
+				onBoundary = true
+			}
+			segments = append(segments, [2]*Vertex{a, b})
+		}
+	}
+
+	const epsilon = 1e-7
+	var angles []float64
+	for _, s := range segments {
+		for _, w := range s {
+			if w.X == x && w.Y == y {
+				continue
+			}
+			theta := direction(p, w)
+			angles = append(angles, theta-epsilon, theta, theta+epsilon)
+		}
+	}
+
+	type hit struct {
+		angle float64
+		x, y  int
+	}
+	var hits []hit
+	for _, theta := range angles {
+		rel := normalizeAngle(theta - start)
+		if onBoundary && rel > width {
+			continue
+		}
+		hx, hy, ok := castRay(p, math.Cos(theta), math.Sin(theta), segments)
+		if ok {
+			hits = append(hits, hit{rel, int(math.Round(hx)), int(math.Round(hy))})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].angle < hits[j].angle })
+
+	var polygon [][2]int
+	if onBoundary {
+		polygon = append(polygon, [2]int{x, y})
+	}
+	for _, h := range hits {
+		q := [2]int{h.x, h.y}
+		if len(polygon) == 0 || polygon[len(polygon)-1] != q {
+			polygon = append(polygon, q)
+		}
+	}
+	if len(polygon) > 1 && polygon[0] == polygon[len(polygon)-1] {
+		polygon = polygon[:len(polygon)-1]
+	}
+
+	// Rays cast towards hidden vertices hit the edges in front of them, leaving vertices in the
+	// middle of straight runs of the region boundary.
+	vertexOf := func(q [2]int) *Vertex { return &Vertex{X: q[0], Y: q[1]} }
+	for i := 0; i < len(polygon) && len(polygon) > 3; {
+		prev, next := polygon[(i+len(polygon)-1)%len(polygon)], polygon[(i+1)%len(polygon)]
+		if (i > 0 || !onBoundary) && onSegment(vertexOf(polygon[i]), vertexOf(prev), vertexOf(next)) {
+			polygon = append(polygon[:i], polygon[i+1:]...)
+			i = max(i-1, 0)
+			continue
+		}
+		i++
+	}
+	return polygon
+}
+
+// castRay returns the point nearest to p where the ray from p in the direction (dx, dy) hits one of
+// the segments, ignoring the segments that contain p itself. The last result is false if the ray
+// hits no segment.
+func castRay(p *Vertex, dx, dy float64, segments [][2]*Vertex) (float64, float64, bool) {
+	px, py := float64(p.X), float64(p.Y)
+	nearest := math.Inf(1)
+	for _, s := range segments {
+		a, b := s[0], s[1]
+		if orient(a, b, p) == 0 && min(a.X, b.X) <= p.X && p.X <= max(a.X, b.X) &&
+			min(a.Y, b.Y) <= p.Y && p.Y <= max(a.Y, b.Y) {
+			continue
+		}
+		ex, ey := float64(b.X-a.X), float64(b.Y-a.Y)
+		denom := dx*ey - dy*ex
+		if denom == 0 {
+			continue
+		}
+		wx, wy := float64(a.X)-px, float64(a.Y)-py
+		t := (wx*ey - wy*ex) / denom
+		u := (wx*dy - wy*dx) / denom
+		if t > 0 && u >= 0 && u <= 1 && t < nearest {
+			nearest = t
+		}
+	}
+	if math.IsInf(nearest, 1) {
+		return 0, 0, false
+	}
+	return px + nearest*dx, py + nearest*dy, true
+}
+
+// direction returns the angle of the direction from a to b, counter-clockwise from the positive x
+// axis.
+func direction(a, b *Vertex) float64 {
+	return math.Atan2(float64(b.Y-a.Y), float64(b.X-a.X))
+}
+
+// normalizeAngle returns the angle reduced to the range [0, 2*pi).
+func normalizeAngle(angle float64) float64 {
+	angle = math.Mod(angle, 2*math.Pi)
+	if angle < 0 {
+		angle += 2 * math.Pi
+	}
+	return angle
+}
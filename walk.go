@@ -0,0 +1,151 @@
+package dcel
+
+// WalkFaces traverses the faces of the structure breadth-first, starting from the given face and
+// moving from a face to its neighbours across the edges at its boundary and at the boundaries of
+// its holes. The visit function is called once for every reached face, and the traversal stops
+// early if it returns false.
+func (d *DCEL) WalkFaces(start *Face, visit func(f *Face) bool) {
+	d.walkFaces(start, nil, visit)
+}
+
+// walkFaces is like WalkFaces, but only moves across the boundary half-edges for which cross
+// returns true, or across all of them if cross is nil.
+func (d *DCEL) walkFaces(start *Face, cross func(he *HalfEdge) bool, visit func(f *Face) bool) {
+	visited := map[*Face]bool{start: true}
+	queue := []*Face{start}
+	for len(queue) > 0 {
+		face := queue[0]
+		queue = queue[1:]
+		if !visit(face) {
+			return
+		}
+		for _, he := range sides(face) {
+			if he.Twin == nil || he.Twin.Face == nil || visited[he.Twin.Face] {
+				continue
+			}
+			if cross != nil && !cross(he) {
+				continue
+			}
+			visited[he.Twin.Face] = true
+			queue = append(queue, he.Twin.Face)
+		}
+	}
+}
+
+// sides returns the half-edges at the boundary of the face followed by the ones at the boundaries
+// of its holes.
+func sides(f *Face) []*HalfEdge {
+	edges := f.HalfEdges()
+	for _, hole := range f.InnerComponents {
+		edges = append(edges, cycle(hole)...)
+	}
+	return edges
+}
+
+// FaceDistance returns the smallest number of edges that have to be crossed to move from face a to
+// face b, moving between faces like WalkFaces does, or -1 if b can not be reached from a. The
+// distance of a face to itself is zero.
+func (d *DCEL) FaceDistance(a, b *Face) int {
+	dist := map[*Face]int{a: 0}
+	queue := []*Face{a}
+	for len(queue) > 0 {
+		face := queue[0]
+		queue = queue[1:]
+		if face == b {
+			return dist[face]
+		}
+		for _, he := range sides(face) {
+			if he.Twin == nil || he.Twin.Face == nil {
+				continue
+			}
+			if _, ok := dist[he.Twin.Face]; !ok {
+				dist[he.Twin.Face] = dist[face] + 1
+				queue = append(queue, he.Twin.Face)
+			}
+		}
+	}
+	return -1
+}
+
+// WalkVertices traverses the vertices of the structure breadth-first, starting from the given
+// vertex and moving from a vertex to its neighbours along the edges incident to it. The visit
+// function is called once for every reached vertex, and the traversal stops early if it returns
+// false.
+func (d *DCEL) WalkVertices(start *Vertex, visit func(v *Vertex) bool) {
+	adj := d.adjacency()
+	visited := map[*Vertex]bool{start: true}
+	queue := []*Vertex{start}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		if !visit(v) {
+			return
+		}
+		for _, he := range adj[v] {
+			if w := otherEnd(he, v); !visited[w] {
+				visited[w] = true
+				queue = append(queue, w)
+			}
+		}
+	}
+}
+
+// ExteriorFaces returns the set of faces that can be reached from the outer face without crossing a
+// Constrained edge, including the outer face itself. If the boundary of a shape is marked as
+// Constrained, as done for the constraints of BuildConstrainedDelaunay, the result holds the faces
+// outside of the shape, such as the triangles filling its concavities, while the faces inside of it
+// and inside of its holes are left out. The set is empty if OuterFace is not set. To use other
+// edges as the boundary, use ExteriorFacesWithBarrier.
+func (d *DCEL) ExteriorFaces() map[*Face]bool {
+	return d.ExteriorFacesWithBarrier(func(he *HalfEdge) bool { return he.Constrained })
+}
+
+// ExteriorFacesWithBarrier returns the set of faces that can be reached from the outer face without
+// crossing an edge for which barrier returns true, including the outer face itself. The barrier
+// edges usually describe the boundary of a shape, so the result tells apart the faces outside of
+// the shape, filling its concavities and holes, from the ones inside it. A nil barrier lets the
+// walk cross any edge. The set is empty if OuterFace is not set.
+func (d *DCEL) ExteriorFacesWithBarrier(barrier func(he *HalfEdge) bool) map[*Face]bool {
+	exterior := make(map[*Face]bool)
+	if d.OuterFace == nil {
+		return exterior
+	}
+	var cross func(he *HalfEdge) bool
+	if barrier != nil {
+		cross = func(he *HalfEdge) bool { return !barrier(he) }
+	}
+	d.walkFaces(d.OuterFace, cross, func(f *Face) bool {
+		exterior[f] = true
+		return true
+	})
+	return exterior
+}
+
+// Cycles returns every closed cycle formed by the Next pointers of the half-edges in the structure,
+// each as the list of its half-edges in Next order. This includes the boundaries
+// of all faces and holes, as well as cycles not referenced by any face. Every half-edge belongs to
+// at most one cycle, so each cycle is returned once. Half-edges whose Next pointers lead to a
+// missing link or into a cycle they are not part of are not returned, and can not cause an
+// infinite loop.
+func (d *DCEL) Cycles() [][]*HalfEdge {
+	var cycles [][]*HalfEdge
+	visited := make(map[*HalfEdge]bool)
+	for _, start := range d.HalfEdges {
+		if visited[start] {
+			continue
+		}
+		position := make(map[*HalfEdge]int)
+		var path []*HalfEdge
+		he := start
+		for he != nil && !visited[he] {
+			visited[he] = true
+			position[he] = len(path)
+			path = append(path, he)
+			he = he.Next
+		}
+		if i, ok := position[he]; ok && he != nil {
+			cycles = append(cycles, path[i:])
+		}
+	}
+	return cycles
+}
@@ -0,0 +1,77 @@
+package dcel
+
+import "testing"
+
+// ring returns constraints joining the points from first to last into a closed ring.
+func ring(first, last int) [][2]int {
+	var constraints [][2]int
+	for i := first; i < last; i++ {
+		constraints = append(constraints, [2]int{i, i + 1})
+	}
+	return append(constraints, [2]int{last, first})
+}
+
+func TestExteriorFacesConcaveShape(t *testing.T) {
+	// A U-shaped polygon, whose notch between x = 10 and x = 20 is filled by bounded triangles of
+	// the triangulation of its convex hull
+	points := [][2]int{{0, 0}, {30, 0}, {30, 30}, {20, 30}, {20, 10}, {10, 10}, {10, 30}, {0, 30}}
+	d, err := BuildConstrainedDelaunay(points, ring(0, len(points)-1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exterior := d.ExteriorFaces()
+	if !exterior[d.OuterFace] {
+		t.Error("ExteriorFaces() does not contain the outer face")
+	}
+	notch := 0
+	for _, f := range d.boundedFaces() {
+		x, y := f.Centroid()
+		inNotch := x > 10 && x < 20 && y > 10
+		if inNotch {
+			notch++
+		}
+		if exterior[f] != inNotch {
+			t.Errorf("face with centroid (%v, %v): exterior = %v, want %v", x, y, exterior[f], inNotch)
+		}
+	}
+	if notch == 0 {
+		t.Fatal("no triangle fills the notch")
+	}
+	if len(exterior) != notch+1 {
+		t.Errorf("ExteriorFaces() has %d faces, want %d", len(exterior), notch+1)
+	}
+}
+
+func TestExteriorFacesHoledShape(t *testing.T) {
+	// A square with a square hole, both of whose boundaries are constrained
+	points := [][2]int{{0, 0}, {40, 0}, {40, 40}, {0, 40}, {10, 10}, {10, 30}, {30, 30}, {30, 10}}
+	d, err := BuildConstrainedDelaunay(points, append(ring(0, 3), ring(4, 7)...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The triangles of the hole are separated from the outside by its constrained boundary
+	exterior := d.ExteriorFaces()
+	if len(exterior) != 1 || !exterior[d.OuterFace] {
+		t.Errorf("ExteriorFaces() = %v, want only the outer face", exterior)
+	}
+	// Without the constraints of the outer square, the walk reaches the triangles of the square
+	// but still not the ones of the hole
+	exterior = d.ExteriorFacesWithBarrier(func(he *HalfEdge) bool {
+		return he.Constrained && !he.IsBoundary()
+	})
+	for _, f := range d.boundedFaces() {
+		x, y := f.Centroid()
+		inHole := x > 10 && x < 30 && y > 10 && y < 30
+		if exterior[f] == inHole {
+			t.Errorf("face with centroid (%v, %v): exterior = %v, want %v", x, y, exterior[f], !inHole)
+		}
+	}
+}
+
+func TestExteriorFacesUnconstrained(t *testing.T) {
+	// Without constrained edges every face is reachable from the outer face
+	d := BuildGrid(2, 2, 10, 10)
+	if got := d.ExteriorFaces(); len(got) != len(d.Faces) {
+		t.Errorf("ExteriorFaces() has %d faces, want %d", len(got), len(d.Faces))
+	}
+}